@@ -0,0 +1,125 @@
+// Command benchcmp compares two stored outputs of `go test -bench`, e.g.
+// before and after a change, and exits with a non-zero status when any
+// benchmark in both regresses significantly beyond the threshold.
+//
+// The difference is tested by the Mann-Whitney U test like benchstat, so
+// each output should have several runs of each benchmark given by `-count`.
+// A smaller value of the unit is regarded as better.
+//
+//	go test -run='^$' -bench=. -count=10 ./combinatorics > old.txt
+//	# change the code
+//	go test -run='^$' -bench=. -count=10 ./combinatorics > new.txt
+//	benchcmp old.txt new.txt
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"text/tabwriter"
+
+	"github.com/ikngtty/benchmark-go-combinatorics/internal/benchresult"
+)
+
+func main() {
+	unit := flag.String("unit", "ns/op", "the `unit` of the metric to compare")
+	alpha := flag.Float64("alpha", 0.05, "the significance level of the p-value")
+	threshold := flag.Float64("threshold", 0.05,
+		"the relative increase regarded as a regression, e.g. 0.05 for 5%")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: benchcmp [flags] old.txt new.txt")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	regressed, err := run(os.Stdout, flag.Arg(0), flag.Arg(1), *unit, *alpha, *threshold)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "benchcmp:", err)
+		os.Exit(2)
+	}
+	if regressed {
+		os.Exit(1)
+	}
+}
+
+func run(w io.Writer, beforeFile, afterFile, unit string, alpha, threshold float64) (bool, error) {
+	before, err := parseFile(beforeFile)
+	if err != nil {
+		return false, err
+	}
+	after, err := parseFile(afterFile)
+	if err != nil {
+		return false, err
+	}
+
+	comparisons := benchresult.Compare(before.Results, after.Results, unit)
+	if len(comparisons) == 0 {
+		return false, fmt.Errorf("no benchmarks of %s in both", unit)
+	}
+	if err := writeComparisons(w, comparisons, unit, alpha); err != nil {
+		return false, err
+	}
+
+	regressions := findRegressions(comparisons, alpha, threshold)
+	for _, c := range regressions {
+		fmt.Fprintf(w, "regression: %s: %+.2f%% (p=%.3f)\n", c.Name, c.Delta*100, c.P)
+	}
+	return len(regressions) > 0, nil
+}
+
+func parseFile(name string) (*benchresult.Set, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	set, err := benchresult.Parse(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+	return set, nil
+}
+
+// writeComparisons writes a table like benchstat. The delta is "~" if it is
+// not significant.
+func writeComparisons(w io.Writer, comparisons []benchresult.Comparison, unit string, alpha float64) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "name\told %s\tnew %s\tdelta\t\n", unit, unit)
+	for _, c := range comparisons {
+		delta := "~"
+		if c.P < alpha {
+			delta = fmt.Sprintf("%+.2f%%", c.Delta*100)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t(p=%.3f n=%d+%d)\n",
+			c.Name, formatSummary(c.Before), formatSummary(c.After), delta,
+			c.P, len(c.Before.Samples), len(c.After.Samples))
+	}
+	return tw.Flush()
+}
+
+func formatSummary(summary benchresult.Summary) string {
+	s := benchresult.FormatValue(summary.Mean)
+	if !math.IsNaN(summary.Margin) && summary.Mean != 0 {
+		s += fmt.Sprintf(" ±%.0f%%", summary.Margin/summary.Mean*100)
+	}
+	return s
+}
+
+// findRegressions finds the comparisons which increase significantly beyond
+// the threshold.
+func findRegressions(comparisons []benchresult.Comparison, alpha, threshold float64) []benchresult.Comparison {
+	regressions := []benchresult.Comparison{}
+	for _, c := range comparisons {
+		if c.P < alpha && c.Delta > threshold {
+			regressions = append(regressions, c)
+		}
+	}
+	return regressions
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const beforeOutput = `goos: linux
+BenchmarkCombinations/WithCarrying0/n=24,k=12-4 10 100 ns/op
+BenchmarkCombinations/WithCarrying0/n=24,k=12-4 10 101 ns/op
+BenchmarkCombinations/WithCarrying0/n=24,k=12-4 10 99 ns/op
+BenchmarkCombinations/WithCarrying0/n=24,k=12-4 10 102 ns/op
+BenchmarkCombinations/WithCarrying0/n=24,k=12-4 10 98 ns/op
+BenchmarkPermutations/Recursive6/n=10,k=10-4 10 200 ns/op
+BenchmarkPermutations/Recursive6/n=10,k=10-4 10 201 ns/op
+BenchmarkPermutations/Recursive6/n=10,k=10-4 10 199 ns/op
+BenchmarkPermutations/Recursive6/n=10,k=10-4 10 202 ns/op
+BenchmarkPermutations/Recursive6/n=10,k=10-4 10 198 ns/op
+`
+
+func writeTemp(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestRun(t *testing.T) {
+	before := writeTemp(t, "old.txt", beforeOutput)
+
+	cases := []struct {
+		name      string
+		after     string
+		regressed bool
+	}{
+		{"same", beforeOutput, false},
+		{"faster", strings.ReplaceAll(beforeOutput, " 10 10", " 10 9"), false},
+		// +10% for WithCarrying0 and within the noise for Recursive6
+		{"slower",
+			strings.NewReplacer(" 10 10", " 10 11", " 10 99 ", " 10 109 ", " 10 98 ", " 10 108 ").
+				Replace(beforeOutput),
+			true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			after := writeTemp(t, "new.txt", c.after)
+			var out strings.Builder
+			regressed, err := run(&out, before, after, "ns/op", 0.05, 0.05)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if regressed != c.regressed {
+				t.Errorf("regressed: want: %v, got: %v\n%s", c.regressed, regressed, out.String())
+			}
+			if c.regressed &&
+				!strings.Contains(out.String(), "regression: Combinations/WithCarrying0/n=24,k=12") {
+				t.Errorf("no regression of WithCarrying0:\n%s", out.String())
+			}
+			if strings.Contains(out.String(), "regression: Permutations") {
+				t.Errorf("unexpected regression of Recursive6:\n%s", out.String())
+			}
+		})
+	}
+}
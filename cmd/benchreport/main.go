@@ -0,0 +1,199 @@
+// Command benchreport summarizes the output of `go test -bench` into
+// Markdown tables grouped by family, with the mean, the 95% confidence
+// interval, the speed relative to the fastest variant and the memory
+// allocated. It also exports
+// the results as JSON or CSV records with the environment for dashboards.
+//
+// It reads the output from the files given as arguments or the standard
+// input, or runs the benchmarks itself with the flag -bench.
+//
+//	go test -run='^$' -bench=. -count=5 ./combinatorics | benchreport
+//	benchreport -bench=Combinations -count=5 -readme=README.md
+//	benchreport -bench=. -format=csv > results.csv
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/ikngtty/benchmark-go-combinatorics/internal/benchresult"
+)
+
+type options struct {
+	bench     string
+	count     int
+	pkg       string
+	unit      string
+	readme    string
+	format    string
+	goVersion string
+	commit    string
+	files     []string
+}
+
+func main() {
+	var opts options
+	flag.StringVar(&opts.bench, "bench", "",
+		"run the benchmarks matching the `regexp` instead of reading the output")
+	flag.IntVar(&opts.count, "count", 5, "the number of runs of each benchmark with -bench")
+	flag.StringVar(&opts.pkg, "pkg", "./combinatorics", "the `package` to benchmark with -bench")
+	flag.StringVar(&opts.unit, "unit", "ns/op", "the `unit` of the metric to report in Markdown")
+	flag.StringVar(&opts.readme, "readme", "",
+		"rewrite the section of the `file` between the markers instead of "+
+			"writing to the standard output")
+	flag.StringVar(&opts.format, "format", "markdown", "the output `format`: markdown, json or csv")
+	flag.StringVar(&opts.goVersion, "go-version", "",
+		"the `version` of Go which ran the benchmarks, detected with -bench")
+	flag.StringVar(&opts.commit, "commit", "",
+		"the git `commit` which was benchmarked, detected with -bench")
+	flag.Parse()
+	opts.files = flag.Args()
+
+	if err := run(opts); err != nil {
+		fmt.Fprintln(os.Stderr, "benchreport:", err)
+		os.Exit(1)
+	}
+}
+
+func run(opts options) error {
+	if opts.readme != "" && opts.format != "markdown" {
+		return fmt.Errorf("-readme needs -format=markdown, got %s", opts.format)
+	}
+
+	output, err := benchOutput(opts)
+	if err != nil {
+		return err
+	}
+	set, err := benchresult.Parse(bytes.NewReader(output))
+	if err != nil {
+		return err
+	}
+
+	switch opts.format {
+	case "markdown":
+		return writeReport(opts, set)
+	case "json", "csv":
+		export := benchresult.Export{
+			Environment: environment(opts, set.Config),
+			Records:     benchresult.Records(set.Results),
+		}
+		if opts.format == "json" {
+			return benchresult.WriteJSON(os.Stdout, export)
+		}
+		return benchresult.WriteCSV(os.Stdout, export)
+	default:
+		return fmt.Errorf("unknown format: %s", opts.format)
+	}
+}
+
+func writeReport(opts options, set *benchresult.Set) error {
+	summaries := benchresult.Summarize(set.Results, opts.unit)
+	if len(summaries) == 0 {
+		return fmt.Errorf("no results of %s", opts.unit)
+	}
+	columns := [][]benchresult.Summary{}
+	for _, unit := range memoryUnits(opts.unit) {
+		columns = append(columns, benchresult.Summarize(set.Results, unit))
+	}
+	var report bytes.Buffer
+	writeEnvironment(&report, set.Config)
+	if err := benchresult.WriteMarkdown(&report, summaries, columns...); err != nil {
+		return err
+	}
+
+	if opts.readme == "" {
+		_, err := os.Stdout.Write(report.Bytes())
+		return err
+	}
+	return rewriteReadme(opts.readme, report.String())
+}
+
+// memoryUnits returns the units of the memory per the same thing as the unit
+// of the time, e.g. B/op and allocs/op for ns/op, or B/pattern and
+// allocs/pattern for ns/pattern. It returns nil for another unit.
+func memoryUnits(unit string) []string {
+	per, ok := strings.CutPrefix(unit, "ns/")
+	if !ok {
+		return nil
+	}
+	return []string{"B/" + per, "allocs/" + per}
+}
+
+// writeEnvironment writes a line of the platform where the benchmarks ran.
+func writeEnvironment(w io.Writer, config map[string]string) {
+	goos, goarch, cpu := config["goos"], config["goarch"], config["cpu"]
+	if goos == "" || goarch == "" {
+		return
+	}
+	fmt.Fprintf(w, "Measured on %s/%s", goos, goarch)
+	if cpu != "" {
+		fmt.Fprintf(w, " (%s)", cpu)
+	}
+	fmt.Fprint(w, ".\n\n")
+}
+
+// environment prefers the flags to the output. The version of Go and
+// the commit are detected only when the benchmarks run here, since stored
+// output may come from another machine.
+func environment(opts options, config map[string]string) benchresult.Environment {
+	env := benchresult.EnvironmentOf(config)
+	if opts.bench != "" {
+		if env.GoVersion == "" {
+			env.GoVersion = commandOutput("go", "env", "GOVERSION")
+		}
+		if env.Commit == "" {
+			env.Commit = commandOutput("git", "rev-parse", "HEAD")
+		}
+	}
+	if opts.goVersion != "" {
+		env.GoVersion = opts.goVersion
+	}
+	if opts.commit != "" {
+		env.Commit = opts.commit
+	}
+	return env
+}
+
+// commandOutput returns the trimmed output of the command, or "" if it fails.
+func commandOutput(name string, args ...string) string {
+	output, err := exec.Command(name, args...).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// benchOutput runs the benchmarks if -bench is given, or reads the files, or
+// reads the standard input.
+func benchOutput(opts options) ([]byte, error) {
+	if opts.bench != "" {
+		cmd := exec.Command("go", "test", "-run=^$", "-bench="+opts.bench, "-benchmem",
+			fmt.Sprintf("-count=%d", opts.count), opts.pkg)
+		var output bytes.Buffer
+		// The progress is shown since the benchmarks take long.
+		cmd.Stdout = io.MultiWriter(&output, os.Stderr)
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			return nil, err
+		}
+		return output.Bytes(), nil
+	}
+
+	if len(opts.files) == 0 {
+		return io.ReadAll(os.Stdin)
+	}
+	var output bytes.Buffer
+	for _, file := range opts.files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		output.Write(content)
+	}
+	return output.Bytes(), nil
+}
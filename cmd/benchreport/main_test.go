@@ -0,0 +1,19 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMemoryUnits(t *testing.T) {
+	cases := map[string][]string{
+		"ns/op":      {"B/op", "allocs/op"},
+		"ns/pattern": {"B/pattern", "allocs/pattern"},
+		"B/op":       nil,
+	}
+	for unit, want := range cases {
+		if got := memoryUnits(unit); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: want: %v, got: %v", unit, want, got)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	beginMarker = "<!-- benchreport:begin -->"
+	endMarker   = "<!-- benchreport:end -->"
+)
+
+func rewriteReadme(path, report string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	rewritten, err := replaceSection(string(content), report)
+	if err != nil {
+		return fmt.Errorf("%s: %v", path, err)
+	}
+	return os.WriteFile(path, []byte(rewritten), 0o644)
+}
+
+// replaceSection replaces the lines between the markers with the report.
+// The markers themselves are kept.
+func replaceSection(doc, report string) (string, error) {
+	begin := strings.Index(doc, beginMarker)
+	if begin < 0 {
+		return "", fmt.Errorf("no marker %q", beginMarker)
+	}
+	begin += len(beginMarker)
+	end := strings.Index(doc[begin:], endMarker)
+	if end < 0 {
+		return "", fmt.Errorf("no marker %q after %q", endMarker, beginMarker)
+	}
+	end += begin
+
+	if !strings.HasSuffix(report, "\n") {
+		report += "\n"
+	}
+	return doc[:begin] + "\n\n" + report + "\n" + doc[end:], nil
+}
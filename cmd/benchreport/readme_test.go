@@ -0,0 +1,38 @@
+package main
+
+import "testing"
+
+func TestReplaceSection(t *testing.T) {
+	doc := "# Title\n\n" +
+		"<!-- benchreport:begin -->\nold\n<!-- benchreport:end -->\n\n" +
+		"## Footer\n"
+	want := "# Title\n\n" +
+		"<!-- benchreport:begin -->\n\nnew\n\n<!-- benchreport:end -->\n\n" +
+		"## Footer\n"
+
+	got, err := replaceSection(doc, "new")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("want: %q, got: %q", want, got)
+	}
+
+	again, err := replaceSection(got, "new\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again != want {
+		t.Errorf("not idempotent: want: %q, got: %q", want, again)
+	}
+
+	for _, doc := range []string{
+		"no markers",
+		"<!-- benchreport:begin --> only",
+		"<!-- benchreport:end --> <!-- benchreport:begin -->",
+	} {
+		if _, err := replaceSection(doc, "new"); err == nil {
+			t.Errorf("%q: want an error", doc)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/ikngtty/benchmark-go-combinatorics/combinatorics"
+)
+
+// fastest enumerate with the fastest implementations of the families. See
+// the doc of the command for permutations.
+var fastest = map[combinatorics.Family]func(n, k int, f func([]int)) error{
+	combinatorics.FamilyCombinations:    combinatorics.Combinations,
+	combinatorics.FamilyDupCombinations: combinatorics.DupCombinations,
+	combinatorics.FamilyPermutations:    combinatorics.Permutations,
+	combinatorics.FamilyDupPermutations: combinatorics.DupPermutations,
+}
+
+var iterators = map[combinatorics.Family]func(n, k int, order combinatorics.Order) *combinatorics.Iterator{
+	combinatorics.FamilyCombinations:    combinatorics.NewCombinationIterator,
+	combinatorics.FamilyDupCombinations: combinatorics.NewDupCombinationIterator,
+	combinatorics.FamilyPermutations:    combinatorics.NewPermutationIterator,
+	combinatorics.FamilyDupPermutations: combinatorics.NewDupPermutationIterator,
+}
+
+// enumerate calls `f` for the patterns of the ranks in the range until `f`
+// returns false. `count` is the number of all patterns.
+func enumerate(opts options, count int, f func([]int) bool) error {
+	if opts.order == combinatorics.Lex && opts.from == 0 && opts.to == count {
+		// The implementations cannot stop, so that it skips the rest after
+		// `f` returns false.
+		ok := true
+		return fastest[opts.family](opts.n, opts.k, func(pattern []int) {
+			if ok {
+				ok = f(pattern)
+			}
+		})
+	}
+
+	it := iterators[opts.family](opts.n, opts.k, opts.order)
+	it.Seek(opts.from)
+	for rank := opts.from; rank < opts.to; rank++ {
+		// The iterator must not stop before `count` checked by the caller.
+		if !it.Next() {
+			return fmt.Errorf("the iterator stopped at %d before %d", rank, opts.to)
+		}
+		if !f(it.Pattern()) {
+			break
+		}
+	}
+	return nil
+}
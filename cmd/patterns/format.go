@@ -0,0 +1,120 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/ikngtty/benchmark-go-combinatorics/combinatorics"
+)
+
+// patternWriter writes a pattern in a format. Close must be called after
+// the last pattern.
+type patternWriter interface {
+	Write(pattern []int) error
+	Close() error
+}
+
+func newPatternWriter(opts options, w io.Writer) (patternWriter, error) {
+	switch opts.format {
+	case "text":
+		return &textWriter{w: w, items: opts.items}, nil
+	case "csv":
+		return &csvWriter{w: csv.NewWriter(w), items: opts.items}, nil
+	case "jsonl":
+		return &jsonlWriter{encoder: json.NewEncoder(w), items: opts.items}, nil
+	case "binary":
+		encoder, err := combinatorics.NewEncoder(w, combinatorics.Header{
+			Family:   opts.family,
+			N:        opts.n,
+			K:        opts.k,
+			Encoding: opts.encoding,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &binaryWriter{encoder: encoder}, nil
+	default:
+		return nil, fmt.Errorf("unknown format: %s", opts.format)
+	}
+}
+
+// textWriter writes a line for each pattern, separated by spaces.
+type textWriter struct {
+	w     io.Writer
+	items []string
+}
+
+func (tw *textWriter) Write(pattern []int) error {
+	_, err := io.WriteString(tw.w, strings.Join(strs(pattern, tw.items), " ")+"\n")
+	return err
+}
+
+func (tw *textWriter) Close() error {
+	return nil
+}
+
+type csvWriter struct {
+	w     *csv.Writer
+	items []string
+}
+
+func (cw *csvWriter) Write(pattern []int) error {
+	if err := cw.w.Write(strs(pattern, cw.items)); err != nil {
+		return err
+	}
+	// The buffer is flushed for each pattern since it is buffered outside.
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+func (cw *csvWriter) Close() error {
+	return nil
+}
+
+// jsonlWriter writes a JSON array for each line, of numbers or of the items.
+type jsonlWriter struct {
+	encoder *json.Encoder
+	items   []string
+}
+
+func (jw *jsonlWriter) Write(pattern []int) error {
+	if jw.items == nil {
+		return jw.encoder.Encode(pattern)
+	}
+	return jw.encoder.Encode(strs(pattern, jw.items))
+}
+
+func (jw *jsonlWriter) Close() error {
+	return nil
+}
+
+// binaryWriter writes the stream of combinatorics.Encoder, ignoring
+// the items.
+type binaryWriter struct {
+	encoder *combinatorics.Encoder
+}
+
+func (bw *binaryWriter) Write(pattern []int) error {
+	return bw.encoder.Encode(pattern)
+}
+
+func (bw *binaryWriter) Close() error {
+	return bw.encoder.Close()
+}
+
+// strs converts the pattern into the items, or into the numbers.
+func strs(pattern []int, items []string) []string {
+	s := make([]string, len(pattern))
+	for i, num := range pattern {
+		if items != nil {
+			s[i] = items[num]
+		} else {
+			s[i] = strconv.Itoa(num)
+		}
+	}
+	return s
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ikngtty/benchmark-go-combinatorics/combinatorics"
+)
+
+func TestRun(t *testing.T) {
+	cases := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"-family=combi", "-n=4", "-k=2"},
+			"0 1\n0 2\n0 3\n1 2\n1 3\n2 3\n"},
+		{[]string{"-family=dupcombi", "-items=x,y", "-k=2", "-format=csv"},
+			"x,x\nx,y\ny,y\n"},
+		{[]string{"-family=perm", "-n=3", "-k=2", "-format=jsonl", "-order=revlex"},
+			"[2,1]\n[2,0]\n[1,2]\n[1,0]\n[0,2]\n[0,1]\n"},
+		{[]string{"-family=perm", "-items=a,b,c", "-k=3", "-format=jsonl", "-from=2", "-to=4"},
+			"[\"b\",\"a\",\"c\"]\n[\"b\",\"c\",\"a\"]\n"},
+		{[]string{"-family=combi", "-n=5", "-k=3", "-order=colex", "-from=7"},
+			"0 3 4\n1 3 4\n2 3 4\n"},
+		{[]string{"-family=dupperm", "-n=2", "-k=2", "-to=100"},
+			"0 0\n0 1\n1 0\n1 1\n"},
+		{[]string{"-family=dupperm", "-n=0", "-k=0"},
+			"\n"},
+		{[]string{"-family=combi", "-n=3", "-k=3", "-from=1"},
+			""},
+		// C(62, 31) is close to the limit of int.
+		{[]string{"-family=combi", "-n=62", "-k=31", "-from=0", "-to=3"},
+			"0 1 2 3 4 5 6 7 8 9 10 11 12 13 14 15 16 17 18 19 20 21 22 23 24 25 26 27 28 29 30\n" +
+				"0 1 2 3 4 5 6 7 8 9 10 11 12 13 14 15 16 17 18 19 20 21 22 23 24 25 26 27 28 29 31\n" +
+				"0 1 2 3 4 5 6 7 8 9 10 11 12 13 14 15 16 17 18 19 20 21 22 23 24 25 26 27 28 29 32\n"},
+		{[]string{"-family=combi", "-n=62", "-k=31", "-order=revlex", "-to=1"},
+			"31 32 33 34 35 36 37 38 39 40 41 42 43 44 45 46 47 48 49 50 51 52 53 54 55 56 57 58 59 60 61\n"},
+		{[]string{"-family=dupcombi", "-n=100", "-k=10", "-count"},
+			"42634215112710\n"},
+		{[]string{"-family=perm", "-n=20", "-k=20", "-count"},
+			"2432902008176640000\n"},
+	}
+
+	for _, c := range cases {
+		var out bytes.Buffer
+		if err := run(c.args, &out); err != nil {
+			t.Errorf("%v: %v", c.args, err)
+			continue
+		}
+		if got := out.String(); got != c.want {
+			t.Errorf("%v: want: %q, got: %q", c.args, c.want, got)
+		}
+	}
+}
+
+func TestRunBinary(t *testing.T) {
+	for _, encoding := range []string{"bits", "rank", "delta"} {
+		var out bytes.Buffer
+		args := []string{"-family=dupperm", "-n=200", "-k=2", "-from=399", "-to=401",
+			"-format=binary", "-encoding=" + encoding}
+		if err := run(args, &out); err != nil {
+			t.Fatal(err)
+		}
+
+		d, err := combinatorics.NewDecoder(&out)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := combinatorics.Header{
+			Family: combinatorics.FamilyDupPermutations, N: 200, K: 2,
+			Encoding: d.Header().Encoding,
+		}
+		if d.Header() != want || !strings.EqualFold(want.Encoding.String(), encoding) {
+			t.Errorf("%s: want: %+v, got: %+v", encoding, want, d.Header())
+		}
+
+		// ranks 399 and 400 are [1 199] and [2 0]
+		got := [][]int{}
+		for {
+			pattern := make([]int, 2)
+			if err := d.Decode(pattern); err == io.EOF {
+				break
+			} else if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, pattern)
+		}
+		if !reflect.DeepEqual(got, [][]int{{1, 199}, {2, 0}}) {
+			t.Errorf("%s: got: %v", encoding, got)
+		}
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	cases := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"-n=3", "-k=2"}, "-family is required"},
+		{[]string{"-family=foo", "-n=3"}, "unknown family"},
+		{[]string{"-family=combi", "-k=2"}, "either -n or -items"},
+		{[]string{"-family=combi", "-n=2", "-items=a,b,c"}, "differs"},
+		{[]string{"-family=combi", "-n=2", "-k=3"}, "k exceeds n"},
+		{[]string{"-family=perm", "-n=-1"}, "negative n or k"},
+		{[]string{"-family=perm", "-n=21", "-k=21"}, "overflows"},
+		{[]string{"-family=perm", "-n=3", "-order=foo"}, "unknown order"},
+		{[]string{"-family=perm", "-n=3", "-format=foo"}, "unknown format"},
+		{[]string{"-family=perm", "-n=3", "-encoding=foo"}, "unknown encoding"},
+		{[]string{"-family=perm", "-n=3", "-k=2", "-from=3", "-to=2"}, "exceeds -to"},
+		{[]string{"-family=perm", "-n=3", "extra"}, "unexpected arguments"},
+	}
+
+	for _, c := range cases {
+		var out bytes.Buffer
+		err := run(c.args, &out)
+		if err == nil || !strings.Contains(err.Error(), c.want) {
+			t.Errorf("%v: want an error of %q, got: %v", c.args, c.want, err)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package combinatorics
+
+import "testing"
+
+// allocSizes are the sizes for which allocPins pin the allocation counts.
+var allocSizes = []benchSize{
+	{n: 0, k: 0},
+	{n: 3, k: 1},
+	{n: 5, k: 3},
+	{n: 6, k: 6},
+	{n: 8, k: 4},
+}
+
+// allocPins are the allocation counts per enumeration of the implementations
+// with a callback for each of allocSizes. Most of them allocate only
+// the pattern, which is not counted for `k = 0`. Linked stacks allocate for
+// each push, slice stacks for each growth, pooled stacks for each chunk of
+// nodes, and all of them for themselves since they escape as container.Stack.
+// Stacks of pointers, as of PermutationsWithStack0, also allocate for each
+// item, and some recursive permutations for each call. A change which starts
+// allocating for each pattern breaks the pins.
+var allocPins = []struct {
+	family Family
+	name   string
+	want   []float64
+}{
+	// Combinations
+	{FamilyCombinations, "Recursive1", []float64{0, 1, 1, 1, 1}},
+	{FamilyCombinations, "Recursive2", []float64{1, 1, 1, 1, 1}},
+	{FamilyCombinations, "WithStack0", []float64{2, 6, 22, 9, 128}},
+	{FamilyCombinations, "WithSlice0", []float64{2, 5, 6, 3, 8}},
+	{FamilyCombinations, "WithStackPooled0", []float64{2, 3, 3, 3, 4}},
+	{FamilyCombinations, "WithCarrying0", []float64{0, 1, 1, 1, 1}},
+	{FamilyCombinations, "WithCarrying1", []float64{0, 1, 1, 1, 1}},
+	// DupCombinations
+	{FamilyDupCombinations, "Recursive1", []float64{0, 1, 1, 1, 1}},
+	{FamilyDupCombinations, "Recursive2", []float64{1, 1, 1, 1, 1}},
+	{FamilyDupCombinations, "WithStack0", []float64{2, 6, 58, 926, 497}},
+	{FamilyDupCombinations, "WithSlice0", []float64{2, 5, 7, 8, 8}},
+	{FamilyDupCombinations, "WithStackPooled0", []float64{2, 3, 3, 4, 4}},
+	{FamilyDupCombinations, "WithCarrying0", []float64{0, 1, 1, 1, 1}},
+	{FamilyDupCombinations, "WithCarrying1", []float64{0, 1, 1, 1, 1}},
+	// Permutations
+	{FamilyPermutations, "Recursive2", []float64{0, 10, 496, 12349, 16321}},
+	{FamilyPermutations, "Recursive3", []float64{0, 7, 411, 11113, 14241}},
+	{FamilyPermutations, "Recursive4", []float64{2, 17, 592, 13274, 19282}},
+	{FamilyPermutations, "Recursive5", []float64{0, 7, 171, 3913, 4161}},
+	{FamilyPermutations, "Recursive6", []float64{0, 1, 1, 1, 1}},
+	{FamilyPermutations, "Recursive7", []float64{0, 1, 1, 1, 1}},
+	{FamilyPermutations, "WithStack0", []float64{3, 10, 174, 3916, 4164}},
+	{FamilyPermutations, "WithStack1", []float64{2, 6, 88, 1959, 2083}},
+	{FamilyPermutations, "WithStack2", []float64{3, 10, 174, 3916, 4164}},
+	{FamilyPermutations, "WithStack3", []float64{2, 6, 88, 1959, 2083}},
+	{FamilyPermutations, "WithStack4", []float64{1, 5, 87, 1958, 2082}},
+	{FamilyPermutations, "WithStack5", []float64{2, 9, 173, 3195, 4163}},
+	{FamilyPermutations, "WithStack6", []float64{2, 6, 88, 1959, 2083}},
+	{FamilyPermutations, "WithStack7", []float64{2, 12, 258, 5871, 6243}},
+	{FamilyPermutations, "WithStack8", []float64{7, 27, 519, 11745, 12489}},
+	{FamilyPermutations, "WithSlice0", []float64{2, 4, 5, 6, 6}},
+	{FamilyPermutations, "WithSlice1", []float64{2, 4, 5, 6, 6}},
+	{FamilyPermutations, "WithSlice2", []float64{2, 5, 7, 7, 8}},
+	{FamilyPermutations, "WithSlice4", []float64{1, 5, 7, 7, 8}},
+	{FamilyPermutations, "WithSlice5", []float64{2, 8, 92, 1243, 2088}},
+	{FamilyPermutations, "WithSlice6", []float64{2, 5, 7, 7, 8}},
+	{FamilyPermutations, "WithSlice7", []float64{2, 7, 8, 9, 10}},
+	{FamilyPermutations, "WithSlice8", []float64{7, 22, 269, 5883, 6256}},
+	{FamilyPermutations, "WithStackPooled0", []float64{2, 3, 3, 3, 3}},
+	{FamilyPermutations, "WithStackPooled1", []float64{2, 3, 3, 3, 3}},
+	{FamilyPermutations, "WithStackPooled2", []float64{2, 3, 3, 3, 4}},
+	{FamilyPermutations, "WithStackPooled3", []float64{2, 3, 3, 3, 4}},
+	{FamilyPermutations, "WithStackPooled4", []float64{1, 3, 3, 3, 4}},
+	{FamilyPermutations, "WithStackPooled5", []float64{2, 6, 88, 1239, 2084}},
+	{FamilyPermutations, "WithStackPooled6", []float64{2, 3, 3, 3, 4}},
+	{FamilyPermutations, "WithStackPooled7", []float64{2, 3, 4, 5, 5}},
+	{FamilyPermutations, "WithStackPooled8", []float64{7, 18, 265, 5879, 6251}},
+	{FamilyPermutations, "WithCarrying0", []float64{0, 1, 1, 1, 1}},
+	{FamilyPermutations, "WithCarrying1", []float64{0, 1, 1, 1, 1}},
+	{FamilyPermutations, "WithCarrying2", []float64{0, 1, 1, 1, 1}},
+	// DupPermutations
+	{FamilyDupPermutations, "Recursive1", []float64{0, 1, 1, 1, 1}},
+	{FamilyDupPermutations, "WithStack0", []float64{2, 6, 158, 55989, 4683}},
+	{FamilyDupPermutations, "WithSlice0", []float64{2, 5, 7, 8, 8}},
+	{FamilyDupPermutations, "WithStackPooled0", []float64{2, 3, 3, 4, 4}},
+	{FamilyDupPermutations, "WithCarrying0", []float64{0, 1, 1, 1, 1}},
+	{FamilyDupPermutations, "WithCarrying1", []float64{0, 1, 1, 1, 1}},
+	{FamilyDupPermutations, "WithBaseConverting0", []float64{0, 1, 1, 1, 1}},
+}
+
+func TestAllocs(t *testing.T) {
+	pinned := map[string]bool{}
+	for _, pin := range allocPins {
+		impl, ok := LookupImplementation(pin.family, pin.name)
+		if !ok {
+			t.Errorf("%s%s: not found", pin.family, pin.name)
+			continue
+		}
+		pinned[impl.FullName()] = true
+
+		for i, size := range allocSizes {
+			if got := countAllocs(impl, size.n, size.k); got != pin.want[i] {
+				t.Errorf("%s %v: want: %v allocs, got: %v",
+					impl.FullName(), size, pin.want[i], got)
+			}
+		}
+	}
+
+	for _, impl := range AllImplementations() {
+		signature := impl.Signature()
+		if signature != SignatureCallback && signature != SignatureABasedCallback {
+			continue
+		}
+		if !pinned[impl.FullName()] {
+			t.Errorf("%s: no pin of allocations", impl.FullName())
+		}
+	}
+}
+
+// countAllocs counts the allocations of an enumeration with a callback which
+// does nothing. `a` for an implementation based on `a` is prepared outside.
+func countAllocs(impl Implementation, n, k int) float64 {
+	if fn, ok := impl.Func.(func(a []int, k int, f func([]int))); ok {
+		a := numbers(n)
+		return testing.AllocsPerRun(10, func() {
+			fn(a, k, ignorePattern)
+		})
+	}
+	return testing.AllocsPerRun(10, func() {
+		impl.Each(n, k, ignorePattern)
+	})
+}
+
+func ignorePattern([]int) {}
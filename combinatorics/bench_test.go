@@ -0,0 +1,144 @@
+package combinatorics
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var benchSizesFlag = flag.String("sizes", "",
+	"comma-separated `n:k` pairs to benchmark, e.g. \"24:12,100:3\". "+
+		"It can also be set by the environment variable "+benchSizesEnv+".")
+
+const benchSizesEnv = "COMBINATORICS_BENCH_SIZES"
+
+type benchSize struct {
+	n, k int
+}
+
+func (size benchSize) String() string {
+	return fmt.Sprintf("n=%d,k=%d", size.n, size.k)
+}
+
+func doSomethingForPattern(pattern []int) {
+	total := 0
+	for i := 1; i < len(pattern); i++ {
+		total += pattern[i] - pattern[i-1]
+	}
+}
+
+// benchmarkFamily runs sub-benchmarks of the implementations of the family
+// for each size. The sizes are given by the flag or the environment variable,
+// or `defaultSizes` are used.
+func benchmarkFamily(b *testing.B, family Family, defaultSizes []benchSize) {
+	sizes, err := benchSizes(defaultSizes)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	for _, impl := range Implementations(family) {
+		b.Run(impl.Name, func(b *testing.B) {
+			for _, size := range sizes {
+				b.Run(size.String(), func(b *testing.B) {
+					count := family.Count(size.n, size.k)
+					benchmarkPerPattern(b, count, func() {
+						impl.Each(size.n, size.k, doSomethingForPattern)
+					})
+					reportStackStats(b, impl, size, count)
+				})
+			}
+		})
+	}
+}
+
+// benchmarkPerPattern runs `enumerate` b.N times, and reports the time,
+// the allocations and the allocated bytes per pattern as well so that
+// the results of different sizes are comparable. They are measured outside
+// the timed loop.
+func benchmarkPerPattern(b *testing.B, count int, enumerate func()) {
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	b.ResetTimer()
+
+	for try := 0; try < b.N; try++ {
+		enumerate()
+	}
+
+	b.StopTimer()
+	runtime.ReadMemStats(&after)
+
+	if count == 0 {
+		return
+	}
+	patterns := float64(b.N) * float64(count)
+	b.ReportMetric(float64(b.Elapsed().Nanoseconds())/patterns, "ns/pattern")
+	b.ReportMetric(float64(after.Mallocs-before.Mallocs)/patterns, "allocs/pattern")
+	b.ReportMetric(float64(after.TotalAlloc-before.TotalAlloc)/patterns, "B/pattern")
+}
+
+// reportStackStats reports the pushes per pattern and the max depth of
+// the stack, which are computed from the shape of the tree of patterns, not to
+// perturb the timed loop.
+func reportStackStats(b *testing.B, impl Implementation, size benchSize, count int) {
+	if impl.Stack == NoStack || count == 0 {
+		return
+	}
+	pushes, maxDepth := impl.StackStats(size.n, size.k)
+	b.ReportMetric(float64(pushes)/float64(count), "pushes/pattern")
+	b.ReportMetric(float64(maxDepth), "max-stack-depth")
+}
+
+func benchSizes(defaultSizes []benchSize) ([]benchSize, error) {
+	s := *benchSizesFlag
+	if s == "" {
+		s = os.Getenv(benchSizesEnv)
+	}
+	if s == "" {
+		return defaultSizes, nil
+	}
+	return parseBenchSizes(s)
+}
+
+func parseBenchSizes(s string) ([]benchSize, error) {
+	sizes := []benchSize{}
+	for _, pair := range strings.Split(s, ",") {
+		nk := strings.Split(strings.TrimSpace(pair), ":")
+		if len(nk) != 2 {
+			return nil, fmt.Errorf("invalid size %q: want n:k", pair)
+		}
+		n, err := strconv.Atoi(nk[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid size %q: %v", pair, err)
+		}
+		k, err := strconv.Atoi(nk[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid size %q: %v", pair, err)
+		}
+		if n < 0 || k < 0 {
+			return nil, fmt.Errorf("invalid size %q: negative", pair)
+		}
+		sizes = append(sizes, benchSize{n: n, k: k})
+	}
+	return sizes, nil
+}
+
+func TestParseBenchSizes(t *testing.T) {
+	got, err := parseBenchSizes("24:12, 100:3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []benchSize{{n: 24, k: 12}, {n: 100, k: 3}}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+
+	for _, s := range []string{"24", "24:x", "-1:3", "1:2:3"} {
+		if _, err := parseBenchSizes(s); err == nil {
+			t.Errorf("%q: want an error", s)
+		}
+	}
+}
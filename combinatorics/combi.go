@@ -1,5 +1,7 @@
 package combinatorics
 
+import "github.com/ikngtty/benchmark-go-combinatorics/internal/container"
+
 // CombinationsRecursive0 is a naive recursive implementation.
 func CombinationsRecursive0(begin, end, k int) [][]int {
 	if k == 0 {
@@ -61,12 +63,17 @@ func CombinationsRecursive2(n, k int, f func([]int)) {
 
 // CombinationsWithStack0 stores nodes of permutations in a stack.
 func CombinationsWithStack0(n, k int, f func([]int)) {
-	patternNodeStack := newPatternNodeStack3()
+	combinationsWithStack0(n, k, f, container.NewLinkedStack[patternNode3]())
+}
+
+// combinationsWithStack0 is the body of CombinationsWithStack0 with the stack
+// given.
+func combinationsWithStack0(n, k int, f func([]int), patternNodeStack container.Stack[patternNode3]) {
 	pattern := make([]int, k)
 
-	patternNodeStack.push(patternNode3{pos: -1, number: -1})
-	for !patternNodeStack.empty() {
-		patternNode := patternNodeStack.pop()
+	patternNodeStack.Push(patternNode3{pos: -1, number: -1})
+	for !patternNodeStack.Empty() {
+		patternNode := patternNodeStack.Pop()
 
 		if patternNode.pos > -1 {
 			pattern[patternNode.pos] = patternNode.number
@@ -81,7 +88,7 @@ func CombinationsWithStack0(n, k int, f func([]int)) {
 			childNode := patternNode3{
 				pos: patternNode.pos + 1, number: num,
 			}
-			patternNodeStack.push(childNode)
+			patternNodeStack.Push(childNode)
 		}
 	}
 }
@@ -89,36 +96,24 @@ func CombinationsWithStack0(n, k int, f func([]int)) {
 // CombinationsWithSlice0 bases on CombinationsWithStack0, but the stack
 // is implemented with a slice, not with a pointer.
 func CombinationsWithSlice0(n, k int, f func([]int)) {
-	patternNodeStack := make([]patternNode3, 1)
-	pattern := make([]int, k)
-
-	patternNodeStack[0] = patternNode3{pos: -1, number: -1}
-	for len(patternNodeStack) > 0 {
-		patternNode := patternNodeStack[len(patternNodeStack)-1]      // pop(peek)
-		patternNodeStack = patternNodeStack[:len(patternNodeStack)-1] // pop(discard)
-
-		if patternNode.pos > -1 {
-			pattern[patternNode.pos] = patternNode.number
-		}
-
-		if patternNode.pos == k-1 {
-			f(pattern)
-			continue
-		}
+	combinationsWithStack0(n, k, f, container.NewSliceStack[patternNode3]())
+}
 
-		for num := n + patternNode.pos - k + 1; num >= patternNode.number+1; num-- {
-			childNode := patternNode3{
-				pos: patternNode.pos + 1, number: num,
-			}
-			patternNodeStack = append(patternNodeStack, childNode) // push
-		}
-	}
+// CombinationsWithStackPooled0 bases on CombinationsWithStack0 with a pooled
+// stack.
+func CombinationsWithStackPooled0(n, k int, f func([]int)) {
+	combinationsWithStack0(n, k, f, container.NewPooledStack[patternNode3]())
 }
 
 // CombinationsWithCarrying0 decides the next digit of combination
 // to increment not by recursive calls but by the previous combination
-// directly.
+// directly. See NextCombination for the increment.
 func CombinationsWithCarrying0(n, k int, f func([]int)) {
+	if k > n {
+		// no patterns
+		return
+	}
+
 	pattern := make([]int, k)
 	for i := range pattern {
 		pattern[i] = i
@@ -127,27 +122,8 @@ func CombinationsWithCarrying0(n, k int, f func([]int)) {
 	for {
 		f(pattern)
 
-		pos := k - 1
-		for {
-			if pos == -1 {
-				return
-			}
-
-			oldNum := pattern[pos]
-			if oldNum == n+pos-k {
-				// carry
-				pos--
-				continue
-			}
-
-			// increment
-			pattern[pos]++
-			break
-		}
-
-		// replace the numbers of carried digits
-		for pos++; pos < k; pos++ {
-			pattern[pos] = pattern[pos-1] + 1
+		if !NextCombination(n, pattern) {
+			return
 		}
 	}
 }
@@ -156,6 +132,11 @@ func CombinationsWithCarrying0(n, k int, f func([]int)) {
 // which goes from right digit to left digit, and the one for setting rest
 // values, which goes from left digit to right digit.
 func CombinationsWithCarrying1(n, k int, f func([]int)) {
+	if k > n {
+		// no patterns
+		return
+	}
+
 	pattern := make([]int, k)
 	for i := range pattern {
 		pattern[i] = i
@@ -187,3 +168,86 @@ func CombinationsWithCarrying1(n, k int, f func([]int)) {
 		pos++
 	}
 }
+
+// NextCombination rearranges `pattern` into the next combination in
+// lexicographic order. If `pattern` is the last one, it rearranges `pattern`
+// into the first one and returns false.
+func NextCombination(n int, pattern []int) bool {
+	k := len(pattern)
+
+	pos := k - 1
+	for {
+		if pos == -1 {
+			for i := range pattern {
+				pattern[i] = i
+			}
+			return false
+		}
+
+		oldNum := pattern[pos]
+		if oldNum == n+pos-k {
+			// carry
+			pos--
+			continue
+		}
+
+		// increment
+		pattern[pos]++
+		break
+	}
+
+	// replace the numbers of carried digits
+	for pos++; pos < k; pos++ {
+		pattern[pos] = pattern[pos-1] + 1
+	}
+	return true
+}
+
+// PrevCombination rearranges `pattern` into the previous combination in
+// lexicographic order. If `pattern` is the first one, it rearranges `pattern`
+// into the last one and returns false.
+func PrevCombination(n int, pattern []int) bool {
+	k := len(pattern)
+
+	pos := k - 1
+	for {
+		if pos == -1 {
+			for i := range pattern {
+				pattern[i] = n - k + i
+			}
+			return false
+		}
+
+		minNum := 0
+		if pos > 0 {
+			minNum = pattern[pos-1] + 1
+		}
+		if pattern[pos] == minNum {
+			// borrow
+			pos--
+			continue
+		}
+
+		// decrement
+		pattern[pos]--
+		break
+	}
+
+	// replace the numbers of borrowed digits
+	for pos++; pos < k; pos++ {
+		pattern[pos] = n - k + pos
+	}
+	return true
+}
+
+// CombinationCount computes the number of combinations. The intermediate
+// products do not overflow unless the result does, which Family.CheckedCount
+// detects.
+func CombinationCount(n, k int) int {
+	if k < 0 || k > n {
+		return 0
+	}
+
+	count, _ := checkedCombinationCount(n, k)
+	return count
+}
@@ -0,0 +1,302 @@
+package combinatorics
+
+import "fmt"
+
+// PermutationsByCycleType enumerates the permutations of `n` numbers whose
+// cycles have the lengths of `cycleType` in any order, where `n` is the sum
+// of the lengths. For example, the cycle type [2 2] gives the three pairings
+// of 4 numbers. The patterns are Permutation-compatible, which map `i` to
+// `pattern[i]`, and are not in lexicographic order. The same memory space is
+// reused for each pattern. It panics if a length is not positive.
+func PermutationsByCycleType(cycleType []int, f func([]int)) {
+	n := 0
+	// lengths[l] is the number of the cycles of length l to make
+	lengths := map[int]int{}
+	for _, l := range cycleType {
+		if l < 1 {
+			panic("combinatorics: a cycle length is not positive")
+		}
+		n += l
+		lengths[l]++
+	}
+	distinct := []int{}
+	for l := 1; l <= n; l++ {
+		if lengths[l] > 0 {
+			distinct = append(distinct, l)
+		}
+	}
+
+	pattern := make([]int, n)
+	used := make([]bool, n)
+
+	// fillCycle fills the cycle from `prev` with `rest` more numbers, which
+	// goes back to `start`.
+	var nextCycle func()
+	var fillCycle func(start, prev, rest int)
+	fillCycle = func(start, prev, rest int) {
+		if rest == 0 {
+			pattern[prev] = start
+			nextCycle()
+			return
+		}
+		for num := start + 1; num < n; num++ {
+			if used[num] {
+				continue
+			}
+			used[num] = true
+			pattern[prev] = num
+			fillCycle(start, num, rest-1)
+			used[num] = false
+		}
+	}
+	// nextCycle starts a cycle from the smallest unused number so that each
+	// permutation is made only once.
+	nextCycle = func() {
+		start := 0
+		for start < n && used[start] {
+			start++
+		}
+		if start == n {
+			f(pattern)
+			return
+		}
+
+		used[start] = true
+		for _, l := range distinct {
+			if lengths[l] == 0 {
+				continue
+			}
+			lengths[l]--
+			fillCycle(start, start, l-1)
+			lengths[l]++
+		}
+		used[start] = false
+	}
+	nextCycle()
+}
+
+// CycleTypeCount computes the number of the permutations of the cycle
+// type, which is n! / (l1^m1 m1! l2^m2 m2! ...) for m1 cycles of length l1,
+// m2 cycles of length l2 and so on. It multiplies only factors of the result,
+// so that the intermediate products do not overflow unless the result does.
+// It returns 0 if the result overflows int, which CheckedCycleTypeCount
+// reports. It panics if a length is not positive like
+// PermutationsByCycleType.
+func CycleTypeCount(cycleType []int) int {
+	count, _ := CheckedCycleTypeCount(cycleType)
+	return count
+}
+
+// CheckedCycleTypeCount is CycleTypeCount which returns an error wrapping
+// ErrCountOverflow if the number overflows int.
+func CheckedCycleTypeCount(cycleType []int) (int, error) {
+	n := 0
+	lengths := map[int]int{}
+	for _, l := range cycleType {
+		if l < 1 {
+			panic("combinatorics: a cycle length is not positive")
+		}
+		n += l
+		lengths[l]++
+	}
+
+	// choose the numbers of the cycles of each length, and then make the
+	// cycles one by one from the smallest number left, which fixes the order
+	// of the cycles of the same length
+	ans := 1
+	ok := true
+	mul := func(factor int, factorOK bool) {
+		if ok && factorOK {
+			ans, ok = checkedMul(ans, factor)
+		} else {
+			ok = false
+		}
+	}
+	rest := n
+	for l, m := range lengths {
+		mul(checkedCombinationCount(rest, l*m))
+		rest -= l * m
+		for left := l * m; left > 0; left -= l {
+			mul(checkedCombinationCount(left-1, l-1))
+			mul(checkedPermutationCount(l-1, l-1))
+		}
+	}
+	if !ok {
+		return 0, fmt.Errorf("combinatorics: cycle type %v: %w", cycleType, ErrCountOverflow)
+	}
+	return ans, nil
+}
+
+// Involutions enumerates the permutations of `n` numbers which are their own
+// inverses, that is, whose cycles have the length 1 or 2. The patterns are
+// like those of PermutationsByCycleType. There are no patterns for negative
+// `n`.
+func Involutions(n int, f func([]int)) {
+	if n < 0 {
+		// no patterns
+		return
+	}
+	pattern := make([]int, n)
+	used := make([]bool, n)
+
+	var body func(start int)
+	body = func(start int) {
+		for start < n && used[start] {
+			start++
+		}
+		if start == n {
+			f(pattern)
+			return
+		}
+
+		used[start] = true
+		// a fixed point
+		pattern[start] = start
+		body(start + 1)
+		// a transposition
+		for num := start + 1; num < n; num++ {
+			if used[num] {
+				continue
+			}
+			used[num] = true
+			pattern[start], pattern[num] = num, start
+			body(start + 1)
+			used[num] = false
+		}
+		used[start] = false
+	}
+	body(0)
+}
+
+// InvolutionCount computes the number of involutions of `n` numbers. It
+// returns 0 for negative `n`, or if the number overflows int, which
+// CheckedInvolutionCount reports.
+func InvolutionCount(n int) int {
+	count, _ := CheckedInvolutionCount(n)
+	return count
+}
+
+// CheckedInvolutionCount is InvolutionCount which returns an error wrapping
+// ErrNegative for negative `n`, or ErrCountOverflow if the number overflows
+// int.
+func CheckedInvolutionCount(n int) (int, error) {
+	if n < 0 {
+		return 0, fmt.Errorf("combinatorics: involutions of n=%d: %w", n, ErrNegative)
+	}
+
+	// I(n) = I(n-1) + (n-1) I(n-2)
+	prev, cur := 1, 1
+	for i := 2; i <= n; i++ {
+		next, ok := checkedMul(i-1, prev)
+		if ok {
+			next, ok = checkedAdd(cur, next)
+		}
+		if !ok {
+			return 0, fmt.Errorf("combinatorics: involutions of n=%d: %w", n, ErrCountOverflow)
+		}
+		prev, cur = cur, next
+	}
+	return cur, nil
+}
+
+// CyclicPermutations enumerates the permutations of `n` numbers which
+// consist of a single cycle of length `n`. The patterns are like those of
+// PermutationsByCycleType. There are no such permutations for `n = 0`.
+func CyclicPermutations(n int, f func([]int)) {
+	if n == 0 {
+		// no patterns
+		return
+	}
+	PermutationsByCycleType([]int{n}, f)
+}
+
+// CyclicPermutationCount computes the number of cyclic permutations of `n`
+// numbers, which is (n-1)!. It returns 0 if the number overflows int, which
+// CheckedStirlingFirst(n, 1) reports.
+func CyclicPermutationCount(n int) int {
+	return StirlingFirst(n, 1)
+}
+
+// PermutationsByCycleCount enumerates the permutations of `n` numbers which
+// consist of exactly `k` cycles, including fixed points. The patterns are
+// like those of PermutationsByCycleType.
+func PermutationsByCycleCount(n, k int, f func([]int)) {
+	if k > n || (n > 0 && k == 0) {
+		// no patterns
+		return
+	}
+
+	pattern := make([]int, n)
+
+	// Following c(n, k) = c(n-1, k-1) + (n-1) c(n-1, k), the number `m`
+	// makes a new cycle or is inserted after a smaller number in its cycle.
+	var body func(m, cycles int)
+	body = func(m, cycles int) {
+		if m == n {
+			f(pattern)
+			return
+		}
+
+		rest := n - m
+		if cycles+rest > k {
+			// insert
+			for prev := 0; prev < m; prev++ {
+				pattern[m] = pattern[prev]
+				pattern[prev] = m
+				body(m+1, cycles)
+				pattern[prev] = pattern[m]
+			}
+		}
+		if cycles < k {
+			// a new cycle
+			pattern[m] = m
+			body(m+1, cycles+1)
+		}
+	}
+	body(0, 0)
+}
+
+// StirlingFirst computes the unsigned Stirling number of the first kind
+// c(n, k), which is the number of the permutations of `n` numbers with
+// exactly `k` cycles. It returns 0 for negative `n` or `k`, or if the number
+// overflows int, which CheckedStirlingFirst reports.
+func StirlingFirst(n, k int) int {
+	count, _ := CheckedStirlingFirst(n, k)
+	return count
+}
+
+// CheckedStirlingFirst is StirlingFirst which returns an error wrapping
+// ErrNegative for negative `n` or `k`, or ErrCountOverflow if the number
+// overflows int.
+func CheckedStirlingFirst(n, k int) (int, error) {
+	if n < 0 || k < 0 {
+		return 0, fmt.Errorf("combinatorics: Stirling number of n=%d, k=%d: %w",
+			n, k, ErrNegative)
+	}
+	if k > n {
+		return 0, nil
+	}
+
+	// row[j] is c(i, j). It computes only c(i, j) for `k-j <= n-i`, which
+	// c(n, k) depends on and which do not exceed c(n, k).
+	row := make([]int, k+1)
+	row[0] = 1
+	for i := 1; i <= n; i++ {
+		low := k - (n - i)
+		if low < 1 {
+			low = 1
+		}
+		for j := k; j >= low; j-- {
+			inserted, ok := checkedMul(i-1, row[j])
+			if ok {
+				row[j], ok = checkedAdd(row[j-1], inserted)
+			}
+			if !ok {
+				return 0, fmt.Errorf("combinatorics: Stirling number of n=%d, k=%d: %w",
+					n, k, ErrCountOverflow)
+			}
+		}
+		row[0] = 0
+	}
+	return row[k], nil
+}
@@ -0,0 +1,212 @@
+package combinatorics
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// partitions returns the partitions of `n` into descending parts.
+func partitions(n int) [][]int {
+	var body func(rest, max int) [][]int
+	body = func(rest, max int) [][]int {
+		if rest == 0 {
+			return [][]int{{}}
+		}
+		ans := [][]int{}
+		for part := max; part >= 1; part-- {
+			if part > rest {
+				continue
+			}
+			for _, child := range body(rest-part, part) {
+				ans = append(ans, append([]int{part}, child...))
+			}
+		}
+		return ans
+	}
+	return body(n, n)
+}
+
+// checkFiltered compares the patterns enumerated by `enumerate` with
+// the permutations of `n` numbers which satisfy `filter`, ignoring the order.
+func checkFiltered(t *testing.T, name string, n int, enumerate func(f func([]int)), filter func(Permutation) bool, wantCount int) {
+	t.Helper()
+
+	got := collectInts(enumerate)
+	want := [][]int{}
+	for _, p := range allPermutations(n) {
+		if filter(p) {
+			want = append(want, p)
+		}
+	}
+
+	sortPatterns(got)
+	if len(got) != len(want) || (len(want) > 0 && !reflect.DeepEqual(got, want)) {
+		t.Errorf("%s: want: %v, got: %v", name, want, got)
+	}
+	if wantCount != len(want) {
+		t.Errorf("%s: the count: want: %d, got: %d", name, len(want), wantCount)
+	}
+}
+
+// sortPatterns sorts patterns in lexicographic order, which the patterns of
+// PermutationsRecursive6 are in.
+func sortPatterns(patterns [][]int) {
+	sort.Slice(patterns, func(i, j int) bool {
+		a, b := patterns[i], patterns[j]
+		for pos := range a {
+			if a[pos] != b[pos] {
+				return a[pos] < b[pos]
+			}
+		}
+		return false
+	})
+}
+
+func TestPermutationsByCycleType(t *testing.T) {
+	for n := 0; n <= 6; n++ {
+		for _, cycleType := range partitions(n) {
+			// in another order of the lengths
+			shuffled := append([]int{}, cycleType...)
+			reverseInts(shuffled)
+
+			checkFiltered(t, fmt.Sprint(shuffled), n,
+				func(f func([]int)) { PermutationsByCycleType(shuffled, f) },
+				func(p Permutation) bool { return reflect.DeepEqual(p.CycleType(), cycleType) },
+				CycleTypeCount(shuffled))
+		}
+	}
+}
+
+func TestCycleTypeCount(t *testing.T) {
+	cases := []struct {
+		cycleType []int
+		want      int
+	}{
+		{[]int{}, 1},
+		{[]int{3, 1, 3}, 280},
+		// 23!! for twelve 2-cycles, whose n! overflows
+		{[]int{2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2}, 316234143225},
+		{[]int{20}, 121645100408832000},
+	}
+	for _, c := range cases {
+		if got := CycleTypeCount(c.cycleType); got != c.want {
+			t.Errorf("%v: want: %d, got: %d", c.cycleType, c.want, got)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("want a panic for a non-positive length")
+		}
+	}()
+	CycleTypeCount([]int{0, 3})
+}
+
+func TestCheckedCycleCounts(t *testing.T) {
+	cases := []struct {
+		name    string
+		count   func() (int, error)
+		want    int
+		wantErr error
+	}{
+		{"cycle type [20 1]", func() (int, error) { return CheckedCycleTypeCount([]int{20, 1}) }, 2554547108585472000, nil},
+		{"cycle type [25]", func() (int, error) { return CheckedCycleTypeCount([]int{25}) }, 0, ErrCountOverflow},
+		{"involutions n=31", func() (int, error) { return CheckedInvolutionCount(31) }, 3666624057550245376, nil},
+		{"involutions n=32", func() (int, error) { return CheckedInvolutionCount(32) }, 0, ErrCountOverflow},
+		{"involutions n=60", func() (int, error) { return CheckedInvolutionCount(60) }, 0, ErrCountOverflow},
+		{"involutions n=-3", func() (int, error) { return CheckedInvolutionCount(-3) }, 0, ErrNegative},
+		{"c(21, 1)", func() (int, error) { return CheckedStirlingFirst(21, 1) }, 2432902008176640000, nil},
+		{"c(30, 1)", func() (int, error) { return CheckedStirlingFirst(30, 1) }, 0, ErrCountOverflow},
+		// c(30, 1) overflows, but c(30, 29) does not depend on it
+		{"c(30, 29)", func() (int, error) { return CheckedStirlingFirst(30, 29) }, 435, nil},
+		{"c(-1, 0)", func() (int, error) { return CheckedStirlingFirst(-1, 0) }, 0, ErrNegative},
+	}
+	for _, c := range cases {
+		got, err := c.count()
+		if !errors.Is(err, c.wantErr) {
+			t.Errorf("%s: want the error %v, got %v", c.name, c.wantErr, err)
+		}
+		if got != c.want {
+			t.Errorf("%s: want: %d, got: %d", c.name, c.want, got)
+		}
+	}
+
+	// the unchecked ones return 0 instead of wrapped-around numbers
+	if got := CycleTypeCount([]int{25}); got != 0 {
+		t.Errorf("cycle type [25]: got %d", got)
+	}
+	if got := InvolutionCount(60); got != 0 {
+		t.Errorf("involutions n=60: got %d", got)
+	}
+	if got := InvolutionCount(-3); got != 0 {
+		t.Errorf("involutions n=-3: got %d", got)
+	}
+	if got := StirlingFirst(30, 1); got != 0 {
+		t.Errorf("c(30, 1): got %d", got)
+	}
+}
+
+func TestInvolutions(t *testing.T) {
+	for n := 0; n <= 6; n++ {
+		checkFiltered(t, fmt.Sprintf("n=%d", n), n,
+			func(f func([]int)) { Involutions(n, f) },
+			func(p Permutation) bool { return p.Compose(p).Equal(IdentityPermutation(n)) },
+			InvolutionCount(n))
+	}
+	if got := collectInts(func(f func([]int)) { Involutions(-1, f) }); len(got) != 0 {
+		t.Errorf("n=-1: want no patterns, got %v", got)
+	}
+}
+
+func TestCyclicPermutations(t *testing.T) {
+	for n := 0; n <= 6; n++ {
+		checkFiltered(t, fmt.Sprintf("n=%d", n), n,
+			func(f func([]int)) { CyclicPermutations(n, f) },
+			func(p Permutation) bool { return len(p.CycleDecomposition()) == 1 },
+			CyclicPermutationCount(n))
+	}
+}
+
+func TestPermutationsByCycleCount(t *testing.T) {
+	for n := 0; n <= 6; n++ {
+		for k := 0; k <= n+1; k++ {
+			checkFiltered(t, fmt.Sprintf("n=%d,k=%d", n, k), n,
+				func(f func([]int)) { PermutationsByCycleCount(n, k, f) },
+				func(p Permutation) bool { return p.CycleCount() == k },
+				StirlingFirst(n, k))
+		}
+	}
+}
+
+func TestStirlingFirst(t *testing.T) {
+	// the rows of the triangle
+	want := [][]int{
+		{1},
+		{0, 1},
+		{0, 1, 1},
+		{0, 2, 3, 1},
+		{0, 6, 11, 6, 1},
+		{0, 24, 50, 35, 10, 1},
+	}
+	for n, row := range want {
+		total := 0
+		for k, w := range row {
+			if got := StirlingFirst(n, k); got != w {
+				t.Errorf("c(%d, %d): want: %d, got: %d", n, k, w, got)
+			}
+			total += w
+		}
+		if total != PermutationCount(n, n) {
+			t.Errorf("n=%d: the row sums up to %d", n, total)
+		}
+	}
+	if got := StirlingFirst(3, 4); got != 0 {
+		t.Errorf("k > n: got %d", got)
+	}
+	if got := StirlingFirst(20, 1); got != PermutationCount(19, 19) {
+		t.Errorf("c(20, 1): want 19!, got %d", got)
+	}
+}
@@ -0,0 +1,126 @@
+package combinatorics
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrNotDeBruijn means that a sequence is not a de Bruijn sequence.
+var ErrNotDeBruijn = errors.New("combinatorics: not a de Bruijn sequence")
+
+// DeBruijn constructs the de Bruijn sequence B(n, k), which contains every
+// pattern of DupPermutations of `n` and `k` exactly once as a window of
+// length `k` when it is regarded as cyclic. It concatenates the Lyndon words
+// whose lengths divide `k` in lexicographic order, which gives
+// the lexicographically smallest one of length n^k. It returns nil unless
+// `n` and `k` are positive, or if n^k overflows int.
+func DeBruijn(n, k int) []int {
+	if n < 1 || k < 1 {
+		return nil
+	}
+	total, err := FamilyDupPermutations.CheckedCount(n, k)
+	if err != nil {
+		return nil
+	}
+
+	seq := make([]int, 0, total)
+	prenecklaces(n, k, func(pattern []int, period int) {
+		if k%period == 0 {
+			// the Lyndon word pattern[:period]
+			seq = append(seq, pattern[:period]...)
+		}
+	})
+	return seq
+}
+
+// DeBruijnPreferMax constructs a de Bruijn sequence B(n, k) greedily. From
+// k-1 zeros, it appends the largest number whose window of the last `k`
+// numbers has not appeared yet, until no number can be appended. The greedy
+// sequence ends with `k` zeros, so that dropping the first k-1 zeros makes it
+// cyclic of length n^k. It returns nil unless `n` and `k` are positive, or
+// if n^k+k-1 overflows int.
+func DeBruijnPreferMax(n, k int) []int {
+	if n < 1 || k < 1 {
+		return nil
+	}
+	total, err := FamilyDupPermutations.CheckedCount(n, k)
+	if err != nil || total > math.MaxInt-(k-1) {
+		return nil
+	}
+	seen := make([]bool, total)
+	seq := make([]int, k-1, total+k-1)
+
+	// window is the rank of the last k-1 numbers as a base-n number
+	window := 0
+	high := total / n // the weight of the first number of a window
+	for {
+		appended := false
+		for num := n - 1; num >= 0; num-- {
+			next := window*n + num
+			if seen[next] {
+				continue
+			}
+			seen[next] = true
+			seq = append(seq, num)
+			window = next % high
+			appended = true
+			break
+		}
+		if !appended {
+			break
+		}
+	}
+	return seq[k-1:]
+}
+
+// VerifyDeBruijn checks that `seq` is a de Bruijn sequence B(n, k). It slides
+// a window of length `k` across `seq` regarded as cyclic, and checks
+// the windows against the patterns of DupPermutationsWithCarrying0. The error
+// wraps ErrNotDeBruijn unless `n` or `k` is invalid.
+func VerifyDeBruijn(n, k int, seq []int) error {
+	if n < 1 || k < 1 {
+		return fmt.Errorf("combinatorics: de Bruijn sequence of n=%d, k=%d: not positive", n, k)
+	}
+	total, err := FamilyDupPermutations.CheckedCount(n, k)
+	if err != nil {
+		return err
+	}
+	if len(seq) != total {
+		return fmt.Errorf("%w: the length is %d, not %d", ErrNotDeBruijn, len(seq), total)
+	}
+	for i, num := range seq {
+		if num < 0 || num >= n {
+			return fmt.Errorf("%w: %d at %d is out of range", ErrNotDeBruijn, num, i)
+		}
+	}
+
+	// the positions of the windows by their ranks
+	positions := make([]int, total)
+	for i := range positions {
+		positions[i] = -1
+	}
+	window := make([]int, k)
+	for start := range seq {
+		for i := range window {
+			window[i] = seq[(start+i)%len(seq)]
+		}
+		rank := DupPermutationRank(n, window)
+		if positions[rank] >= 0 {
+			return fmt.Errorf("%w: %v appears at %d and %d",
+				ErrNotDeBruijn, window, positions[rank], start)
+		}
+		positions[rank] = start
+	}
+
+	var missing []int
+	DupPermutationsWithCarrying0(n, k, func(pattern []int) {
+		if missing == nil && positions[DupPermutationRank(n, pattern)] < 0 {
+			missing = append([]int{}, pattern...)
+		}
+	})
+	if missing != nil {
+		return fmt.Errorf("%w: %v does not appear", ErrNotDeBruijn, missing)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package combinatorics
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestDeBruijn(t *testing.T) {
+	constructions := []struct {
+		name      string
+		construct func(n, k int) []int
+	}{
+		{"Lyndon", DeBruijn},
+		{"PreferMax", DeBruijnPreferMax},
+	}
+
+	for _, c := range constructions {
+		for n := 1; n <= 5; n++ {
+			for k := 1; k <= 5; k++ {
+				seq := c.construct(n, k)
+				if err := VerifyDeBruijn(n, k, seq); err != nil {
+					t.Errorf("%s n=%d,k=%d: %v", c.name, n, k, err)
+				}
+			}
+		}
+		if seq := c.construct(0, 3); seq != nil {
+			t.Errorf("%s n=0: want nil, got %v", c.name, seq)
+		}
+		if seq := c.construct(3, 0); seq != nil {
+			t.Errorf("%s k=0: want nil, got %v", c.name, seq)
+		}
+		if seq := c.construct(2, 64); seq != nil {
+			t.Errorf("%s n=2,k=64: want nil for the overflow, got %d numbers", c.name, len(seq))
+		}
+	}
+
+	if got, want := DeBruijn(2, 3), []int{0, 0, 0, 1, 0, 1, 1, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("B(2, 3): want: %v, got: %v", want, got)
+	}
+	if got, want := DeBruijn(3, 2), []int{0, 0, 1, 0, 2, 1, 1, 2, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("B(3, 2): want: %v, got: %v", want, got)
+	}
+	if got, want := DeBruijnPreferMax(2, 3), []int{1, 1, 1, 0, 1, 0, 0, 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("prefer-max B(2, 3): want: %v, got: %v", want, got)
+	}
+}
+
+func TestVerifyDeBruijn(t *testing.T) {
+	invalids := [][]int{
+		{0, 0, 0, 1, 0, 1, 1},       // short
+		{0, 0, 0, 1, 0, 1, 1, 2},    // out of range
+		{0, 0, 0, 1, 1, 0, 1, 1},    // 011 twice, 010 missing
+		{0, 0, 1, 0, 1, 1, 1, 0, 0}, // long
+	}
+	for _, seq := range invalids {
+		if err := VerifyDeBruijn(2, 3, seq); !errors.Is(err, ErrNotDeBruijn) {
+			t.Errorf("%v: want ErrNotDeBruijn, got %v", seq, err)
+		}
+	}
+
+	for _, size := range []benchSize{{0, 3}, {2, 0}, {-1, 2}} {
+		if err := VerifyDeBruijn(size.n, size.k, []int{0}); err == nil || errors.Is(err, ErrNotDeBruijn) {
+			t.Errorf("%v: want an error of the size, got %v", size, err)
+		}
+	}
+	if err := VerifyDeBruijn(2, 64, nil); !errors.Is(err, ErrCountOverflow) {
+		t.Errorf("want ErrCountOverflow, got %v", err)
+	}
+}
@@ -0,0 +1,15 @@
+// Package combinatorics implements enumeration of combinations, combinations
+// with repetition, permutations and permutations with repetition in various
+// ways to compare their performance.
+//
+// The implementations enumerate patterns of `k` numbers from 0 to `n-1` in
+// lexicographic order, and agree on the edge cases:
+//
+//   - `k = 0` gives one empty pattern, even for `n = 0`.
+//   - `k > n` gives no patterns for combinations and permutations.
+//   - `n = 0` and `k > 0` gives no patterns for the families with repetition.
+//
+// The implementations do not check negative `n` or `k`. Combinations,
+// DupCombinations, Permutations and DupPermutations validate them and
+// return errors instead, with the fastest implementation of each family.
+package combinatorics
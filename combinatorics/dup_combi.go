@@ -1,5 +1,7 @@
 package combinatorics
 
+import "github.com/ikngtty/benchmark-go-combinatorics/internal/container"
+
 // DupCombinationsRecursive0 is a naive recursive implementation.
 func DupCombinationsRecursive0(begin, end, k int) [][]int {
 	if k == 0 {
@@ -61,12 +63,17 @@ func DupCombinationsRecursive2(n, k int, f func([]int)) {
 
 // DupCombinationsWithStack0 stores nodes of permutations in a stack.
 func DupCombinationsWithStack0(n, k int, f func([]int)) {
-	patternNodeStack := newPatternNodeStack3()
+	dupCombinationsWithStack0(n, k, f, container.NewLinkedStack[patternNode3]())
+}
+
+// dupCombinationsWithStack0 is the body of DupCombinationsWithStack0 with
+// the stack given.
+func dupCombinationsWithStack0(n, k int, f func([]int), patternNodeStack container.Stack[patternNode3]) {
 	pattern := make([]int, k)
 
-	patternNodeStack.push(patternNode3{pos: -1, number: 0})
-	for !patternNodeStack.empty() {
-		patternNode := patternNodeStack.pop()
+	patternNodeStack.Push(patternNode3{pos: -1, number: 0})
+	for !patternNodeStack.Empty() {
+		patternNode := patternNodeStack.Pop()
 
 		if patternNode.pos > -1 {
 			pattern[patternNode.pos] = patternNode.number
@@ -81,7 +88,7 @@ func DupCombinationsWithStack0(n, k int, f func([]int)) {
 			childNode := patternNode3{
 				pos: patternNode.pos + 1, number: num,
 			}
-			patternNodeStack.push(childNode)
+			patternNodeStack.Push(childNode)
 		}
 	}
 }
@@ -89,63 +96,31 @@ func DupCombinationsWithStack0(n, k int, f func([]int)) {
 // DupCombinationsWithSlice0 bases on DupCombinationsWithStack0, but the stack
 // is implemented with a slice, not with a pointer.
 func DupCombinationsWithSlice0(n, k int, f func([]int)) {
-	patternNodeStack := make([]patternNode3, 1)
-	pattern := make([]int, k)
-
-	patternNodeStack[0] = patternNode3{pos: -1, number: 0}
-	for len(patternNodeStack) > 0 {
-		patternNode := patternNodeStack[len(patternNodeStack)-1]      // pop(peek)
-		patternNodeStack = patternNodeStack[:len(patternNodeStack)-1] // pop(discard)
-
-		if patternNode.pos > -1 {
-			pattern[patternNode.pos] = patternNode.number
-		}
-
-		if patternNode.pos == k-1 {
-			f(pattern)
-			continue
-		}
+	dupCombinationsWithStack0(n, k, f, container.NewSliceStack[patternNode3]())
+}
 
-		for num := n - 1; num >= patternNode.number; num-- {
-			childNode := patternNode3{
-				pos: patternNode.pos + 1, number: num,
-			}
-			patternNodeStack = append(patternNodeStack, childNode) // push
-		}
-	}
+// DupCombinationsWithStackPooled0 bases on DupCombinationsWithStack0 with a
+// pooled stack.
+func DupCombinationsWithStackPooled0(n, k int, f func([]int)) {
+	dupCombinationsWithStack0(n, k, f, container.NewPooledStack[patternNode3]())
 }
 
 // DupCombinationsWithCarrying0 decides the next digit of combination
 // to increment not by recursive calls but by the previous combination
-// directly.
+// directly. See NextDupCombination for the increment.
 func DupCombinationsWithCarrying0(n, k int, f func([]int)) {
+	if n == 0 && k > 0 {
+		// no patterns
+		return
+	}
+
 	pattern := make([]int, k)
 
 	for {
 		f(pattern)
 
-		pos := k - 1
-		for {
-			if pos == -1 {
-				return
-			}
-
-			oldNum := pattern[pos]
-			if oldNum == n-1 {
-				// carry
-				pos--
-				continue
-			}
-
-			// increment
-			pattern[pos]++
-			break
-		}
-
-		// replace the numbers of carried digits
-		numToReplace := pattern[pos]
-		for pos++; pos < k; pos++ {
-			pattern[pos] = numToReplace
+		if !NextDupCombination(n, pattern) {
+			return
 		}
 	}
 }
@@ -154,6 +129,11 @@ func DupCombinationsWithCarrying0(n, k int, f func([]int)) {
 // which goes from right digit to left digit, and the one for setting rest
 // values, which goes from left digit to right digit.
 func DupCombinationsWithCarrying1(n, k int, f func([]int)) {
+	if n == 0 && k > 0 {
+		// no patterns
+		return
+	}
+
 	pattern := make([]int, k)
 
 	pos := k
@@ -182,3 +162,83 @@ func DupCombinationsWithCarrying1(n, k int, f func([]int)) {
 		pos++
 	}
 }
+
+// NextDupCombination rearranges `pattern` into the next combination with
+// repetition in lexicographic order. If `pattern` is the last one, it
+// rearranges `pattern` into the first one and returns false.
+func NextDupCombination(n int, pattern []int) bool {
+	k := len(pattern)
+
+	pos := k - 1
+	for {
+		if pos == -1 {
+			for i := range pattern {
+				pattern[i] = 0
+			}
+			return false
+		}
+
+		oldNum := pattern[pos]
+		if oldNum == n-1 {
+			// carry
+			pos--
+			continue
+		}
+
+		// increment
+		pattern[pos]++
+		break
+	}
+
+	// replace the numbers of carried digits
+	numToReplace := pattern[pos]
+	for pos++; pos < k; pos++ {
+		pattern[pos] = numToReplace
+	}
+	return true
+}
+
+// PrevDupCombination rearranges `pattern` into the previous combination with
+// repetition in lexicographic order. If `pattern` is the first one, it
+// rearranges `pattern` into the last one and returns false.
+func PrevDupCombination(n int, pattern []int) bool {
+	k := len(pattern)
+
+	pos := k - 1
+	for {
+		if pos == -1 {
+			for i := range pattern {
+				pattern[i] = n - 1
+			}
+			return false
+		}
+
+		minNum := 0
+		if pos > 0 {
+			minNum = pattern[pos-1]
+		}
+		if pattern[pos] == minNum {
+			// borrow
+			pos--
+			continue
+		}
+
+		// decrement
+		pattern[pos]--
+		break
+	}
+
+	// replace the numbers of borrowed digits
+	for pos++; pos < k; pos++ {
+		pattern[pos] = n - 1
+	}
+	return true
+}
+
+// DupCombinationCount computes the number of combinations with repetition.
+func DupCombinationCount(n, k int) int {
+	if k == 0 {
+		return 1
+	}
+	return CombinationCount(n+k-1, k)
+}
@@ -7,82 +7,6 @@ import (
 )
 
 func TestDupCombinations(t *testing.T) {
-	targets := []struct {
-		name string
-		f    func(n, k int) [][]int
-	}{
-		{"Recursive0",
-			func(n, k int) [][]int {
-				return DupCombinationsRecursive0(0, n, k)
-			}},
-		{"Recursive1",
-			func(n, k int) [][]int {
-				got := [][]int{}
-				DupCombinationsRecursive1(n, k,
-					func(pattern []int) {
-						patternClone := make([]int, len(pattern))
-						copy(patternClone, pattern)
-						got = append(got, patternClone)
-					})
-				return got
-			}},
-		{"Recursive2",
-			func(n, k int) [][]int {
-				got := [][]int{}
-				DupCombinationsRecursive2(n, k,
-					func(pattern []int) {
-						patternClone := make([]int, len(pattern))
-						copy(patternClone, pattern)
-						got = append(got, patternClone)
-					})
-				return got
-			}},
-		{"WithStack0",
-			func(n, k int) [][]int {
-				got := [][]int{}
-				DupCombinationsWithStack0(n, k,
-					func(pattern []int) {
-						patternClone := make([]int, len(pattern))
-						copy(patternClone, pattern)
-						got = append(got, patternClone)
-					})
-				return got
-			}},
-		{"WithSlice0",
-			func(n, k int) [][]int {
-				got := [][]int{}
-				DupCombinationsWithSlice0(n, k,
-					func(pattern []int) {
-						patternClone := make([]int, len(pattern))
-						copy(patternClone, pattern)
-						got = append(got, patternClone)
-					})
-				return got
-			}},
-		{"WithCarrying0",
-			func(n, k int) [][]int {
-				got := [][]int{}
-				DupCombinationsWithCarrying0(n, k,
-					func(pattern []int) {
-						patternClone := make([]int, len(pattern))
-						copy(patternClone, pattern)
-						got = append(got, patternClone)
-					})
-				return got
-			}},
-		{"WithCarrying1",
-			func(n, k int) [][]int {
-				got := [][]int{}
-				DupCombinationsWithCarrying1(n, k,
-					func(pattern []int) {
-						patternClone := make([]int, len(pattern))
-						copy(patternClone, pattern)
-						got = append(got, patternClone)
-					})
-				return got
-			}},
-	}
-
 	cases := []struct {
 		n, k int
 		want [][]int
@@ -141,11 +65,11 @@ func TestDupCombinations(t *testing.T) {
 		}},
 	}
 
-	for _, target := range targets {
-		t.Run(target.name, func(t *testing.T) {
+	for _, impl := range Implementations(FamilyDupCombinations) {
+		t.Run(impl.Name, func(t *testing.T) {
 			for _, c := range cases {
 				t.Run(fmt.Sprintf("n=%d k=%d", c.n, c.k), func(t *testing.T) {
-					got := target.f(c.n, c.k)
+					got := collectPatterns(t, impl, c.n, c.k)
 					if !reflect.DeepEqual(got, c.want) {
 						t.Errorf("want: %v, got: %v", c.want, got)
 					}
@@ -156,57 +80,9 @@ func TestDupCombinations(t *testing.T) {
 }
 
 func BenchmarkDupCombinations(b *testing.B) {
-	const n = 18
-	const k = 9
-
-	doSomethingForPattern := func(pattern []int) {
-		total := 0
-		for i := 1; i < len(pattern); i++ {
-			total += pattern[i] - pattern[i-1]
-		}
-	}
-
-	targets := []struct {
-		name string
-		f    func()
-	}{
-		{"Recursive0",
-			func() {
-				for _, pattern := range DupCombinationsRecursive0(0, n, k) {
-					doSomethingForPattern(pattern)
-				}
-			}},
-		{"Recursive1",
-			func() {
-				DupCombinationsRecursive1(n, k, doSomethingForPattern)
-			}},
-		{"Recursive2",
-			func() {
-				DupCombinationsRecursive2(n, k, doSomethingForPattern)
-			}},
-		{"WithStack0",
-			func() {
-				DupCombinationsWithStack0(n, k, doSomethingForPattern)
-			}},
-		{"WithSlice0",
-			func() {
-				DupCombinationsWithSlice0(n, k, doSomethingForPattern)
-			}},
-		{"WithCarrying0",
-			func() {
-				DupCombinationsWithCarrying0(n, k, doSomethingForPattern)
-			}},
-		{"WithCarrying1",
-			func() {
-				DupCombinationsWithCarrying1(n, k, doSomethingForPattern)
-			}},
-	}
-
-	for _, target := range targets {
-		b.Run(target.name, func(b *testing.B) {
-			for try := 0; try < b.N; try++ {
-				target.f()
-			}
-		})
-	}
+	benchmarkFamily(b, FamilyDupCombinations, []benchSize{
+		{n: 18, k: 9},
+		{n: 18, k: 3},
+		{n: 100, k: 2},
+	})
 }
@@ -1,5 +1,7 @@
 package combinatorics
 
+import "github.com/ikngtty/benchmark-go-combinatorics/internal/container"
+
 // DupPermutationsRecursive0 is a naive recursive implementation.
 func DupPermutationsRecursive0(n, k int) [][]int {
 	if k == 0 {
@@ -40,12 +42,17 @@ func DupPermutationsRecursive1(n, k int, f func([]int)) {
 
 // DupPermutationsWithStack0 stores nodes of permutations in a stack.
 func DupPermutationsWithStack0(n, k int, f func([]int)) {
-	patternNodeStack := newPatternNodeStack3()
+	dupPermutationsWithStack0(n, k, f, container.NewLinkedStack[patternNode3]())
+}
+
+// dupPermutationsWithStack0 is the body of DupPermutationsWithStack0 with
+// the stack given.
+func dupPermutationsWithStack0(n, k int, f func([]int), patternNodeStack container.Stack[patternNode3]) {
 	pattern := make([]int, k)
 
-	patternNodeStack.push(patternNode3{pos: -1, number: 0})
-	for !patternNodeStack.empty() {
-		patternNode := patternNodeStack.pop()
+	patternNodeStack.Push(patternNode3{pos: -1, number: 0})
+	for !patternNodeStack.Empty() {
+		patternNode := patternNodeStack.Pop()
 
 		if patternNode.pos > -1 {
 			pattern[patternNode.pos] = patternNode.number
@@ -60,7 +67,7 @@ func DupPermutationsWithStack0(n, k int, f func([]int)) {
 			childNode := patternNode3{
 				pos: patternNode.pos + 1, number: num,
 			}
-			patternNodeStack.push(childNode)
+			patternNodeStack.Push(childNode)
 		}
 	}
 }
@@ -68,64 +75,42 @@ func DupPermutationsWithStack0(n, k int, f func([]int)) {
 // DupPermutationsWithSlice0 bases on DupPermutationsWithStack0, but the stack
 // is implemented with a slice, not with a pointer.
 func DupPermutationsWithSlice0(n, k int, f func([]int)) {
-	patternNodeStack := make([]patternNode3, 1)
-	pattern := make([]int, k)
-
-	patternNodeStack[0] = patternNode3{pos: -1, number: 0}
-	for len(patternNodeStack) > 0 {
-		patternNode := patternNodeStack[len(patternNodeStack)-1]      // pop(peek)
-		patternNodeStack = patternNodeStack[:len(patternNodeStack)-1] // pop(discard)
-
-		if patternNode.pos > -1 {
-			pattern[patternNode.pos] = patternNode.number
-		}
-
-		if patternNode.pos == k-1 {
-			f(pattern)
-			continue
-		}
+	dupPermutationsWithStack0(n, k, f, container.NewSliceStack[patternNode3]())
+}
 
-		for num := n - 1; num >= 0; num-- {
-			childNode := patternNode3{
-				pos: patternNode.pos + 1, number: num,
-			}
-			patternNodeStack = append(patternNodeStack, childNode) // push
-		}
-	}
+// DupPermutationsWithStackPooled0 bases on DupPermutationsWithStack0 with a
+// pooled stack.
+func DupPermutationsWithStackPooled0(n, k int, f func([]int)) {
+	dupPermutationsWithStack0(n, k, f, container.NewPooledStack[patternNode3]())
 }
 
 // DupPermutationsWithCarrying0 decides the next digit of permutation
 // to increment not by recursive calls but by the previous permutation
-// directly.
+// directly. See NextDupPermutation for the increment.
 func DupPermutationsWithCarrying0(n, k int, f func([]int)) {
+	if n == 0 && k > 0 {
+		// no patterns
+		return
+	}
+
 	pattern := make([]int, k)
 
 	for {
 		f(pattern)
 
-		pos := k - 1
-		for {
-			if pos == -1 {
-				return
-			}
-
-			oldNum := pattern[pos]
-			if oldNum == n-1 {
-				// carry
-				pattern[pos] = 0
-				pos--
-				continue
-			}
-
-			// increment
-			pattern[pos]++
-			break
+		if !NextDupPermutation(n, pattern) {
+			return
 		}
 	}
 }
 
 // DupPermutationsWithCarrying1 decreases its nests of loop.
 func DupPermutationsWithCarrying1(n, k int, f func([]int)) {
+	if n == 0 && k > 0 {
+		// no patterns
+		return
+	}
+
 	pattern := make([]int, k)
 
 	pos := k
@@ -163,3 +148,46 @@ func DupPermutationsWithBaseConverting0(n, k int, f func([]int)) {
 		f(pattern)
 	}
 }
+
+// NextDupPermutation rearranges `pattern` into the next permutation with
+// repetition in lexicographic order. If `pattern` is the last one, it
+// rearranges `pattern` into the first one and returns false.
+func NextDupPermutation(n int, pattern []int) bool {
+	for pos := len(pattern) - 1; pos >= 0; pos-- {
+		oldNum := pattern[pos]
+		if oldNum == n-1 {
+			// carry
+			pattern[pos] = 0
+			continue
+		}
+
+		// increment
+		pattern[pos]++
+		return true
+	}
+	return false
+}
+
+// PrevDupPermutation rearranges `pattern` into the previous permutation with
+// repetition in lexicographic order. If `pattern` is the first one, it
+// rearranges `pattern` into the last one and returns false.
+func PrevDupPermutation(n int, pattern []int) bool {
+	for pos := len(pattern) - 1; pos >= 0; pos-- {
+		oldNum := pattern[pos]
+		if oldNum == 0 {
+			// borrow
+			pattern[pos] = n - 1
+			continue
+		}
+
+		// decrement
+		pattern[pos]--
+		return true
+	}
+	return false
+}
+
+// DupPermutationCount computes the number of permutations with repetition.
+func DupPermutationCount(n, k int) int {
+	return Pow(n, k)
+}
@@ -7,82 +7,6 @@ import (
 )
 
 func TestDupPermutations(t *testing.T) {
-	targets := []struct {
-		name string
-		f    func(n, k int) [][]int
-	}{
-		{"Recursive0",
-			func(n, k int) [][]int {
-				return DupPermutationsRecursive0(n, k)
-			}},
-		{"Recursive1",
-			func(n, k int) [][]int {
-				got := [][]int{}
-				DupPermutationsRecursive1(n, k,
-					func(pattern []int) {
-						patternClone := make([]int, len(pattern))
-						copy(patternClone, pattern)
-						got = append(got, patternClone)
-					})
-				return got
-			}},
-		{"WithStack0",
-			func(n, k int) [][]int {
-				got := [][]int{}
-				DupPermutationsWithStack0(n, k,
-					func(pattern []int) {
-						patternClone := make([]int, len(pattern))
-						copy(patternClone, pattern)
-						got = append(got, patternClone)
-					})
-				return got
-			}},
-		{"WithSlice0",
-			func(n, k int) [][]int {
-				got := [][]int{}
-				DupPermutationsWithSlice0(n, k,
-					func(pattern []int) {
-						patternClone := make([]int, len(pattern))
-						copy(patternClone, pattern)
-						got = append(got, patternClone)
-					})
-				return got
-			}},
-		{"WithCarrying0",
-			func(n, k int) [][]int {
-				got := [][]int{}
-				DupPermutationsWithCarrying0(n, k,
-					func(pattern []int) {
-						patternClone := make([]int, len(pattern))
-						copy(patternClone, pattern)
-						got = append(got, patternClone)
-					})
-				return got
-			}},
-		{"WithCarrying1",
-			func(n, k int) [][]int {
-				got := [][]int{}
-				DupPermutationsWithCarrying1(n, k,
-					func(pattern []int) {
-						patternClone := make([]int, len(pattern))
-						copy(patternClone, pattern)
-						got = append(got, patternClone)
-					})
-				return got
-			}},
-		{"WithBaseConverting0",
-			func(n, k int) [][]int {
-				got := [][]int{}
-				DupPermutationsWithBaseConverting0(n, k,
-					func(pattern []int) {
-						patternClone := make([]int, len(pattern))
-						copy(patternClone, pattern)
-						got = append(got, patternClone)
-					})
-				return got
-			}},
-	}
-
 	cases := []struct {
 		n, k int
 		want [][]int
@@ -158,11 +82,11 @@ func TestDupPermutations(t *testing.T) {
 		}},
 	}
 
-	for _, target := range targets {
-		t.Run(target.name, func(t *testing.T) {
+	for _, impl := range Implementations(FamilyDupPermutations) {
+		t.Run(impl.Name, func(t *testing.T) {
 			for _, c := range cases {
 				t.Run(fmt.Sprintf("n=%d k=%d", c.n, c.k), func(t *testing.T) {
-					got := target.f(c.n, c.k)
+					got := collectPatterns(t, impl, c.n, c.k)
 					if !reflect.DeepEqual(got, c.want) {
 						t.Errorf("want: %v, got: %v", c.want, got)
 					}
@@ -173,57 +97,10 @@ func TestDupPermutations(t *testing.T) {
 }
 
 func BenchmarkDupPermutations(b *testing.B) {
-	const n = 8
-	const k = 7
-
-	doSomethingForPattern := func(pattern []int) {
-		total := 0
-		for i := 1; i < len(pattern); i++ {
-			total += pattern[i] - pattern[i-1]
-		}
-	}
-
-	targets := []struct {
-		name string
-		f    func()
-	}{
-		{"Recursive0",
-			func() {
-				for _, pattern := range DupPermutationsRecursive0(n, k) {
-					doSomethingForPattern(pattern)
-				}
-			}},
-		{"Recursive1",
-			func() {
-				DupPermutationsRecursive1(n, k, doSomethingForPattern)
-			}},
-		{"WithStack0",
-			func() {
-				DupPermutationsWithStack0(n, k, doSomethingForPattern)
-			}},
-		{"WithSlice0",
-			func() {
-				DupPermutationsWithSlice0(n, k, doSomethingForPattern)
-			}},
-		{"WithCarrying0",
-			func() {
-				DupPermutationsWithCarrying0(n, k, doSomethingForPattern)
-			}},
-		{"WithCarrying1",
-			func() {
-				DupPermutationsWithCarrying1(n, k, doSomethingForPattern)
-			}},
-		{"WithBaseConverting0",
-			func() {
-				DupPermutationsWithBaseConverting0(n, k, doSomethingForPattern)
-			}},
-	}
-
-	for _, target := range targets {
-		b.Run(target.name, func(b *testing.B) {
-			for try := 0; try < b.N; try++ {
-				target.f()
-			}
-		})
-	}
+	benchmarkFamily(b, FamilyDupPermutations, []benchSize{
+		{n: 8, k: 7},
+		{n: 2, k: 20},
+		{n: 8, k: 3},
+		{n: 100, k: 2},
+	})
 }
@@ -0,0 +1,465 @@
+package combinatorics
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+)
+
+// Encoding is a way to pack patterns into bits.
+type Encoding int
+
+const (
+	// EncodingBits packs each number with ceil(log2(n)) bits.
+	EncodingBits Encoding = iota
+	// EncodingRank packs each pattern as its rank in lexicographic order
+	// with ceil(log2(count)) bits. The count must fit in int.
+	EncodingRank
+	// EncodingDelta packs the length of the prefix shared with the previous
+	// pattern with ceil(log2(k+1)) bits, and then the rest of the numbers like
+	// EncodingBits. It suits patterns in lexicographic order, where most of
+	// them change only a short suffix.
+	EncodingDelta
+)
+
+func (encoding Encoding) String() string {
+	switch encoding {
+	case EncodingBits:
+		return "Bits"
+	case EncodingRank:
+		return "Rank"
+	case EncodingDelta:
+		return "Delta"
+	default:
+		return "Encoding(?)"
+	}
+}
+
+// Header describes a stream of patterns. `K` of a stream must not exceed
+// EncodingKLimit.
+type Header struct {
+	Family   Family
+	N, K     int
+	Encoding Encoding
+}
+
+// The stream is the header followed by blocks. A block is the number of
+// patterns and the number of bytes as uvarints, and the packed patterns.
+// A block of no patterns terminates the stream.
+var encodingMagic = [4]byte{'C', 'M', 'B', 'I'}
+
+const (
+	encodingVersion   = 1
+	encoderBlockLimit = 4096 // patterns in a block
+)
+
+// EncodingKLimit is the maximum `k` of a stream. It bounds the memory which
+// Decoder allocates for a header.
+const EncodingKLimit = 1 << 16
+
+var (
+	// ErrInvalidPattern means that the pattern is not of the family of
+	// the stream.
+	ErrInvalidPattern = errors.New("combinatorics: invalid pattern for the stream")
+	// ErrInvalidStream means that the stream is not of Encoder.
+	ErrInvalidStream = errors.New("combinatorics: invalid stream of patterns")
+)
+
+// Encoder writes patterns packed into bits. Close must be called after
+// the last pattern.
+type Encoder struct {
+	w      io.Writer
+	header Header
+	widths encodingWidths
+
+	bw       bitWriter
+	patterns int // in the current block
+	prev     []int
+	hasPrev  bool
+	scratch  []int // to validate patterns
+}
+
+// encodingWidths are the numbers of bits for the encoding.
+type encodingWidths struct {
+	number uint // of a number
+	rank   uint // of a rank, only for EncodingRank
+	prefix uint // of the length of a shared prefix
+}
+
+func newEncodingWidths(header Header) (encodingWidths, error) {
+	if header.K > EncodingKLimit {
+		return encodingWidths{}, fmt.Errorf("combinatorics: k exceeds %d: %d",
+			EncodingKLimit, header.K)
+	}
+	if err := header.Family.validateSize(header.N, header.K); err != nil {
+		return encodingWidths{}, err
+	}
+	widths := encodingWidths{
+		number: bitsFor(header.N),
+		prefix: bitsFor(header.K + 1),
+	}
+	if header.Encoding == EncodingRank {
+		count, err := header.Family.CheckedCount(header.N, header.K)
+		if err != nil {
+			return encodingWidths{}, err
+		}
+		widths.rank = bitsFor(count)
+	}
+	return widths, nil
+}
+
+// maxPatternBits returns the maximum number of bits of a pattern.
+func (widths encodingWidths) maxPatternBits(header Header) uint64 {
+	switch header.Encoding {
+	case EncodingBits:
+		return uint64(header.K) * uint64(widths.number)
+	case EncodingRank:
+		return uint64(widths.rank)
+	default:
+		return uint64(widths.prefix) + uint64(header.K)*uint64(widths.number)
+	}
+}
+
+// bitsFor returns the number of bits for values from 0 to `size-1`.
+func bitsFor(size int) uint {
+	if size <= 1 {
+		return 0
+	}
+	return uint(bits.Len(uint(size - 1)))
+}
+
+// NewEncoder writes the header and returns an Encoder.
+func NewEncoder(w io.Writer, header Header) (*Encoder, error) {
+	if header.Encoding < EncodingBits || header.Encoding > EncodingDelta {
+		return nil, fmt.Errorf("combinatorics: unknown encoding: %d", header.Encoding)
+	}
+	widths, err := newEncodingWidths(header)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := append([]byte{}, encodingMagic[:]...)
+	buf = append(buf, encodingVersion, byte(header.Family), byte(header.Encoding))
+	buf = binary.AppendUvarint(buf, uint64(header.N))
+	buf = binary.AppendUvarint(buf, uint64(header.K))
+	if _, err := w.Write(buf); err != nil {
+		return nil, err
+	}
+
+	return &Encoder{
+		w:       w,
+		header:  header,
+		widths:  widths,
+		prev:    make([]int, header.K),
+		scratch: make([]int, header.K),
+	}, nil
+}
+
+// Encode packs the pattern. It returns ErrInvalidPattern if the pattern is
+// not of the family, `n` and `k` of the header.
+func (e *Encoder) Encode(pattern []int) error {
+	h := e.header
+	if len(pattern) != h.K || !h.Family.validPatternIn(h.N, pattern, e.scratch) {
+		return ErrInvalidPattern
+	}
+
+	switch h.Encoding {
+	case EncodingBits:
+		for _, num := range pattern {
+			e.bw.write(uint64(num), e.widths.number)
+		}
+	case EncodingRank:
+		e.bw.write(uint64(lexRank(h.Family, h.N, pattern)), e.widths.rank)
+	case EncodingDelta:
+		prefix := 0
+		if e.hasPrev {
+			for prefix < h.K && pattern[prefix] == e.prev[prefix] {
+				prefix++
+			}
+		}
+		e.bw.write(uint64(prefix), e.widths.prefix)
+		for _, num := range pattern[prefix:] {
+			e.bw.write(uint64(num), e.widths.number)
+		}
+		copy(e.prev, pattern)
+		e.hasPrev = true
+	}
+
+	e.patterns++
+	if e.patterns == encoderBlockLimit {
+		return e.flushBlock()
+	}
+	return nil
+}
+
+// Close writes the rest of the patterns and the terminator. It does not
+// close the underlying writer.
+func (e *Encoder) Close() error {
+	if e.patterns > 0 {
+		if err := e.flushBlock(); err != nil {
+			return err
+		}
+	}
+	_, err := e.w.Write([]byte{0})
+	return err
+}
+
+func (e *Encoder) flushBlock() error {
+	e.bw.flush()
+
+	buf := binary.AppendUvarint(nil, uint64(e.patterns))
+	buf = binary.AppendUvarint(buf, uint64(len(e.bw.buf)))
+	buf = append(buf, e.bw.buf...)
+	_, err := e.w.Write(buf)
+
+	e.bw.buf = e.bw.buf[:0]
+	e.patterns = 0
+	return err
+}
+
+// Decoder reads patterns written by Encoder.
+type Decoder struct {
+	r      *bufio.Reader
+	header Header
+	widths encodingWidths
+
+	br       bitReader
+	patterns int // left in the current block
+	prev     []int
+	done     bool
+	scratch  []int // to validate patterns
+}
+
+// NewDecoder reads the header and returns a Decoder.
+func NewDecoder(r io.Reader) (*Decoder, error) {
+	br := bufio.NewReader(r)
+
+	var fixed [7]byte
+	if _, err := io.ReadFull(br, fixed[:]); err != nil {
+		return nil, invalidStream(err)
+	}
+	if [4]byte(fixed[:4]) != encodingMagic || fixed[4] != encodingVersion {
+		return nil, ErrInvalidStream
+	}
+	header := Header{Family: Family(fixed[5]), Encoding: Encoding(fixed[6])}
+	if header.Family < FamilyCombinations || header.Family > FamilyDupPermutations ||
+		header.Encoding < EncodingBits || header.Encoding > EncodingDelta {
+		return nil, ErrInvalidStream
+	}
+
+	n, err := readUvarintInt(br)
+	if err != nil {
+		return nil, err
+	}
+	k, err := readUvarintInt(br)
+	if err != nil {
+		return nil, err
+	}
+	header.N, header.K = n, k
+
+	widths, err := newEncodingWidths(header)
+	if err != nil {
+		return nil, ErrInvalidStream
+	}
+	return &Decoder{
+		r:       br,
+		header:  header,
+		widths:  widths,
+		prev:    make([]int, k),
+		scratch: make([]int, k),
+	}, nil
+}
+
+// Header returns the header of the stream.
+func (d *Decoder) Header() Header {
+	return d.header
+}
+
+// Decode fills `pattern`, whose length must be `k` of the header, with
+// the next pattern. It returns io.EOF after the last pattern.
+func (d *Decoder) Decode(pattern []int) error {
+	if len(pattern) != d.header.K {
+		return fmt.Errorf("combinatorics: the length of the pattern is %d, not %d",
+			len(pattern), d.header.K)
+	}
+	for d.patterns == 0 {
+		if d.done {
+			return io.EOF
+		}
+		if err := d.readBlock(); err != nil {
+			return err
+		}
+	}
+
+	h := d.header
+	switch h.Encoding {
+	case EncodingBits:
+		for i := range pattern {
+			pattern[i] = int(d.br.read(d.widths.number))
+		}
+	case EncodingRank:
+		rank := int(d.br.read(d.widths.rank))
+		if rank >= h.Family.Count(h.N, h.K) {
+			return ErrInvalidStream
+		}
+		lexUnrank(h.Family, h.N, rank, pattern)
+	case EncodingDelta:
+		prefix := int(d.br.read(d.widths.prefix))
+		if prefix > h.K {
+			return ErrInvalidStream
+		}
+		copy(pattern[:prefix], d.prev[:prefix])
+		for i := prefix; i < h.K; i++ {
+			pattern[i] = int(d.br.read(d.widths.number))
+		}
+		copy(d.prev, pattern)
+	}
+	if d.br.overrun || !h.Family.validPatternIn(h.N, pattern, d.scratch) {
+		return ErrInvalidStream
+	}
+
+	d.patterns--
+	return nil
+}
+
+func (d *Decoder) readBlock() error {
+	patterns, err := readUvarintInt(d.r)
+	if err != nil {
+		return err
+	}
+	if patterns == 0 {
+		d.done = true
+		return nil
+	}
+	if patterns > encoderBlockLimit {
+		return ErrInvalidStream
+	}
+	size, err := readUvarintInt(d.r)
+	if err != nil {
+		return err
+	}
+	maxBits := uint64(patterns) * d.widths.maxPatternBits(d.header)
+	if uint64(size) > (maxBits+7)/8 {
+		return ErrInvalidStream
+	}
+
+	// It allocates as much as the stream has, not as `size` claims.
+	buf, err := io.ReadAll(io.LimitReader(d.r, int64(size)))
+	if err != nil {
+		return err
+	}
+	if len(buf) < size {
+		return io.ErrUnexpectedEOF
+	}
+	d.br = bitReader{buf: buf}
+	d.patterns = patterns
+	return nil
+}
+
+func readUvarintInt(r io.ByteReader) (int, error) {
+	v, err := binary.ReadUvarint(r)
+	if err != nil {
+		return 0, invalidStream(err)
+	}
+	if v > uint64(int(^uint(0)>>1)) {
+		return 0, ErrInvalidStream
+	}
+	return int(v), nil
+}
+
+// invalidStream regards the end of the stream before the terminator as
+// unexpected.
+func invalidStream(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// bitWriter packs values from the most significant bit.
+type bitWriter struct {
+	buf  []byte
+	acc  uint64
+	nacc uint // the number of bits in `acc`, less than 8 between writes
+}
+
+func (bw *bitWriter) write(v uint64, width uint) {
+	for width > 0 {
+		take := width
+		if take > 8 {
+			take = 8
+		}
+		width -= take
+		bw.acc = bw.acc<<take | (v>>width)&(1<<take-1)
+		bw.nacc += take
+		if bw.nacc >= 8 {
+			bw.nacc -= 8
+			bw.buf = append(bw.buf, byte(bw.acc>>bw.nacc))
+			bw.acc &= 1<<bw.nacc - 1
+		}
+	}
+}
+
+// flush pads the last byte with zeros.
+func (bw *bitWriter) flush() {
+	if bw.nacc > 0 {
+		bw.buf = append(bw.buf, byte(bw.acc<<(8-bw.nacc)))
+		bw.acc, bw.nacc = 0, 0
+	}
+}
+
+type bitReader struct {
+	buf     []byte
+	pos     uint // in bits
+	overrun bool // whether it read beyond `buf`
+}
+
+func (br *bitReader) read(width uint) uint64 {
+	if br.pos+width > uint(len(br.buf))*8 {
+		br.overrun = true
+		return 0
+	}
+
+	var v uint64
+	for width > 0 {
+		avail := 8 - br.pos%8
+		take := avail
+		if take > width {
+			take = width
+		}
+		chunk := uint64(br.buf[br.pos/8]>>(avail-take)) & (1<<take - 1)
+		v = v<<take | chunk
+		br.pos += take
+		width -= take
+	}
+	return v
+}
+
+func lexRank(family Family, n int, pattern []int) int {
+	switch family {
+	case FamilyCombinations:
+		return CombinationRank(n, pattern)
+	case FamilyDupCombinations:
+		return DupCombinationRank(n, pattern)
+	case FamilyPermutations:
+		return PermutationRank(n, pattern)
+	default:
+		return DupPermutationRank(n, pattern)
+	}
+}
+
+func lexUnrank(family Family, n, rank int, pattern []int) {
+	switch family {
+	case FamilyCombinations:
+		CombinationUnrank(n, rank, pattern)
+	case FamilyDupCombinations:
+		DupCombinationUnrank(n, rank, pattern)
+	case FamilyPermutations:
+		PermutationUnrank(n, rank, pattern)
+	default:
+		DupPermutationUnrank(n, rank, pattern)
+	}
+}
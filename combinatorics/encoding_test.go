@@ -0,0 +1,282 @@
+package combinatorics
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var encodings = []Encoding{EncodingBits, EncodingRank, EncodingDelta}
+
+func encodePatterns(t testing.TB, header Header, patterns [][]int) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	e, err := NewEncoder(&buf, header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, pattern := range patterns {
+		if err := e.Encode(pattern); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := e.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func decodePatterns(data []byte) (Header, [][]int, error) {
+	d, err := NewDecoder(bytes.NewReader(data))
+	if err != nil {
+		return Header{}, nil, err
+	}
+	patterns := [][]int{}
+	for {
+		pattern := make([]int, d.Header().K)
+		err := d.Decode(pattern)
+		if err == io.EOF {
+			return d.Header(), patterns, nil
+		}
+		if err != nil {
+			return d.Header(), patterns, err
+		}
+		patterns = append(patterns, pattern)
+	}
+}
+
+func TestEncodingRoundTrip(t *testing.T) {
+	sizes := []benchSize{{0, 0}, {1, 1}, {3, 0}, {3, 5}, {5, 3}, {6, 6}, {9, 4}}
+	for _, family := range Families {
+		impl := Implementations(family)[0]
+		for _, size := range sizes {
+			if err := family.Validate(size.n, size.k); err != nil {
+				// The stream has the same restrictions as the validated API.
+				header := Header{Family: family, N: size.n, K: size.k}
+				if _, err := NewEncoder(io.Discard, header); !errors.Is(err, ErrKExceedsN) {
+					t.Errorf("%v/%v: want ErrKExceedsN, got %v", family, size, err)
+				}
+				continue
+			}
+			want := collectPatterns(t, impl, size.n, size.k)
+			for _, encoding := range encodings {
+				header := Header{Family: family, N: size.n, K: size.k, Encoding: encoding}
+				t.Run(fmt.Sprintf("%v/%v/%v", family, encoding, size), func(t *testing.T) {
+					data := encodePatterns(t, header, want)
+					gotHeader, got, err := decodePatterns(data)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if gotHeader != header {
+						t.Errorf("header: want: %+v, got: %+v", header, gotHeader)
+					}
+					if !reflect.DeepEqual(got, want) {
+						t.Errorf("want: %v, got: %v", want, got)
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestEncodingRoundTripInOrders(t *testing.T) {
+	// Patterns are not necessarily in lexicographic order.
+	n, k := 6, 3
+	iterators := map[Family]func(n, k int, order Order) *Iterator{
+		FamilyCombinations:    NewCombinationIterator,
+		FamilyDupCombinations: NewDupCombinationIterator,
+		FamilyPermutations:    NewPermutationIterator,
+		FamilyDupPermutations: NewDupPermutationIterator,
+	}
+	for _, family := range Families {
+		for _, order := range []Order{RevLex, Colex, RevColex} {
+			it := iterators[family](n, k, order)
+			want := [][]int{}
+			for it.Next() {
+				want = append(want, append([]int{}, it.Pattern()...))
+			}
+			for _, encoding := range encodings {
+				header := Header{Family: family, N: n, K: k, Encoding: encoding}
+				_, got, err := decodePatterns(encodePatterns(t, header, want))
+				if err != nil {
+					t.Fatalf("%v/%v/%v: %v", family, order, encoding, err)
+				}
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("%v/%v/%v: want: %v, got: %v", family, order, encoding, want, got)
+				}
+			}
+		}
+	}
+}
+
+func TestEncodingBlocks(t *testing.T) {
+	// more patterns than a block
+	n, k := 16, 4
+	want := collectPatterns(t, Implementations(FamilyPermutations)[0], n, k)
+	if len(want) <= encoderBlockLimit {
+		t.Fatalf("want more than %d patterns, got %d", encoderBlockLimit, len(want))
+	}
+	for _, encoding := range encodings {
+		header := Header{Family: FamilyPermutations, N: n, K: k, Encoding: encoding}
+		data := encodePatterns(t, header, want)
+		_, got, err := decodePatterns(data)
+		if err != nil {
+			t.Fatalf("%v: %v", encoding, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%v: the patterns differ", encoding)
+		}
+
+		var text strings.Builder
+		for _, pattern := range want {
+			fmt.Fprintln(&text, strings.Trim(fmt.Sprint(pattern), "[]"))
+		}
+		if len(data) >= text.Len()/4 {
+			t.Errorf("%v: %d bytes for %d bytes of text", encoding, len(data), text.Len())
+		}
+	}
+}
+
+func TestEncodingHugeSizes(t *testing.T) {
+	// Only EncodingRank needs the count, which overflows here.
+	want := [][]int{make([]int, 70), append(make([]int, 69), 1)}
+	for _, encoding := range []Encoding{EncodingBits, EncodingDelta} {
+		header := Header{Family: FamilyDupPermutations, N: 2, K: 70, Encoding: encoding}
+		_, got, err := decodePatterns(encodePatterns(t, header, want))
+		if err != nil {
+			t.Fatalf("%v: %v", encoding, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%v: want: %v, got: %v", encoding, want, got)
+		}
+	}
+	header := Header{Family: FamilyDupPermutations, N: 2, K: 70, Encoding: EncodingRank}
+	if _, err := NewEncoder(io.Discard, header); !errors.Is(err, ErrCountOverflow) {
+		t.Errorf("rank: want ErrCountOverflow, got %v", err)
+	}
+
+	// The validation of permutations takes no memory of n.
+	header = Header{Family: FamilyPermutations, N: 1 << 42, K: 1, Encoding: EncodingBits}
+	want = [][]int{{1<<42 - 1}}
+	_, got, err := decodePatterns(encodePatterns(t, header, want))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("huge n: want: %v, got: %v", want, got)
+	}
+}
+
+func TestEncoderErrors(t *testing.T) {
+	if _, err := NewEncoder(io.Discard, Header{Family: FamilyCombinations, N: -1, K: 2}); !errors.Is(err, ErrNegative) {
+		t.Errorf("want ErrNegative, got %v", err)
+	}
+	if _, err := NewEncoder(io.Discard, Header{Family: FamilyCombinations, N: 3, K: 2, Encoding: 9}); err == nil {
+		t.Error("want an error for an unknown encoding")
+	}
+
+	invalids := map[Family][][]int{
+		FamilyCombinations:    {{1}, {1, 1}, {2, 1}, {0, 4}, {-1, 2}},
+		FamilyDupCombinations: {{2, 1}, {0, 4}, {0, 1, 2}},
+		FamilyPermutations:    {{1, 1}, {4, 0}},
+		FamilyDupPermutations: {{4, 0}, {0, -1}, {}},
+	}
+	for family, patterns := range invalids {
+		for _, encoding := range encodings {
+			e, err := NewEncoder(io.Discard, Header{Family: family, N: 4, K: 2, Encoding: encoding})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, pattern := range patterns {
+				if err := e.Encode(pattern); err != ErrInvalidPattern {
+					t.Errorf("%v/%v/%v: want ErrInvalidPattern, got %v", family, encoding, pattern, err)
+				}
+			}
+		}
+	}
+}
+
+func TestDecoderErrors(t *testing.T) {
+	header := Header{Family: FamilyCombinations, N: 5, K: 3, Encoding: EncodingBits}
+	data := encodePatterns(t, header, collectPatterns(t, Implementations(FamilyCombinations)[0], 5, 3))
+
+	if _, _, err := decodePatterns([]byte("text")); err != io.ErrUnexpectedEOF {
+		t.Errorf("short: want io.ErrUnexpectedEOF, got %v", err)
+	}
+	if _, _, err := decodePatterns([]byte("CMBX\x01\x00\x00\x05\x03")); err != ErrInvalidStream {
+		t.Errorf("magic: want ErrInvalidStream, got %v", err)
+	}
+	if _, _, err := decodePatterns(data[:len(data)-1]); err != io.ErrUnexpectedEOF {
+		t.Errorf("no terminator: want io.ErrUnexpectedEOF, got %v", err)
+	}
+	if _, _, err := decodePatterns(data[:len(data)-3]); err != io.ErrUnexpectedEOF {
+		t.Errorf("truncated: want io.ErrUnexpectedEOF, got %v", err)
+	}
+
+	d, err := NewDecoder(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Decode(make([]int, 2)); err == nil {
+		t.Error("want an error for the length of the pattern")
+	}
+
+	// The fields must not make it allocate too much.
+	hugeK := streamHeader(FamilyDupPermutations, EncodingBits, 1, 1<<50)
+	if _, _, err := decodePatterns(hugeK); err != ErrInvalidStream {
+		t.Errorf("huge k: want ErrInvalidStream, got %v", err)
+	}
+	hugeSize := binary.AppendUvarint(streamHeader(FamilyCombinations, EncodingBits, 5, 3), 1)
+	hugeSize = binary.AppendUvarint(hugeSize, 1<<60)
+	if _, _, err := decodePatterns(hugeSize); err != ErrInvalidStream {
+		t.Errorf("huge block: want ErrInvalidStream, got %v", err)
+	}
+	manyPatterns := binary.AppendUvarint(streamHeader(FamilyCombinations, EncodingBits, 5, 3), 1<<40)
+	manyPatterns = binary.AppendUvarint(manyPatterns, 0)
+	if _, _, err := decodePatterns(manyPatterns); err != ErrInvalidStream {
+		t.Errorf("many patterns: want ErrInvalidStream, got %v", err)
+	}
+
+	header = Header{Family: FamilyDupPermutations, N: 1, K: EncodingKLimit + 1}
+	if _, err := NewEncoder(io.Discard, header); err == nil {
+		t.Errorf("want an error for k over %d", EncodingKLimit)
+	}
+}
+
+// streamHeader builds the header of a stream without validation.
+func streamHeader(family Family, encoding Encoding, n, k uint64) []byte {
+	buf := append([]byte{}, encodingMagic[:]...)
+	buf = append(buf, encodingVersion, byte(family), byte(encoding))
+	buf = binary.AppendUvarint(buf, n)
+	return binary.AppendUvarint(buf, k)
+}
+
+func FuzzDecoder(f *testing.F) {
+	for _, family := range Families {
+		for _, encoding := range encodings {
+			header := Header{Family: family, N: 4, K: 2, Encoding: encoding}
+			patterns := collectPatterns(f, Implementations(family)[0], 4, 2)
+			f.Add(encodePatterns(f, header, patterns))
+		}
+	}
+	f.Add(streamHeader(FamilyDupPermutations, EncodingBits, 1, 1<<50))
+	hugeN := binary.AppendUvarint(streamHeader(FamilyPermutations, EncodingBits, 1<<42, 1), 1)
+	hugeN = binary.AppendUvarint(hugeN, 6)
+	f.Add(append(hugeN, 0, 0, 0, 0, 0, 0, 0))
+	f.Add(binary.AppendUvarint(streamHeader(FamilyCombinations, EncodingRank, 5, 3), 1<<60))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		header, patterns, _ := decodePatterns(data)
+		for _, pattern := range patterns {
+			if !header.Family.validPattern(header.N, pattern) {
+				t.Errorf("%+v: invalid pattern: %v", header, pattern)
+			}
+		}
+	})
+}
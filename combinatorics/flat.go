@@ -0,0 +1,84 @@
+package combinatorics
+
+// Flat holds patterns in a single slice instead of a slice for each pattern.
+// The i-th pattern is `Data()[i*k : (i+1)*k]`.
+type Flat struct {
+	data  []int
+	k     int
+	count int // for `k = 0`, where `data` is empty
+}
+
+// Len returns the number of patterns.
+func (flat Flat) Len() int {
+	return flat.count
+}
+
+// K returns the length of each pattern.
+func (flat Flat) K() int {
+	return flat.k
+}
+
+// At returns the i-th pattern. It shares the memory space with Flat, and its
+// capacity is limited so that appending to it does not overwrite the next
+// pattern.
+func (flat Flat) At(i int) []int {
+	begin := i * flat.k
+	end := begin + flat.k
+	return flat.data[begin:end:end]
+}
+
+// Data returns the numbers of all patterns in order.
+func (flat Flat) Data() []int {
+	return flat.data
+}
+
+// Slices returns the patterns as slices sharing the memory space with Flat.
+func (flat Flat) Slices() [][]int {
+	patterns := make([][]int, flat.count)
+	for i := range patterns {
+		patterns[i] = flat.At(i)
+	}
+	return patterns
+}
+
+// CollectCombinations collects all combinations into Flat, which
+// has a single slice for them.
+func CollectCombinations(n, k int) Flat {
+	return collectFlat(CombinationCount(n, k), k, func(f func([]int)) {
+		CombinationsWithCarrying0(n, k, f)
+	})
+}
+
+// CollectDupCombinations collects all combinations with repetition into Flat,
+// which has a single slice for them.
+func CollectDupCombinations(n, k int) Flat {
+	return collectFlat(DupCombinationCount(n, k), k, func(f func([]int)) {
+		DupCombinationsWithCarrying0(n, k, f)
+	})
+}
+
+// CollectPermutations collects all permutations into Flat, which
+// has a single slice for them.
+func CollectPermutations(n, k int) Flat {
+	return collectFlat(PermutationCount(n, k), k, func(f func([]int)) {
+		PermutationsRecursive6(n, k, f)
+	})
+}
+
+// CollectDupPermutations collects all permutations with repetition into Flat,
+// which has a single slice for them.
+func CollectDupPermutations(n, k int) Flat {
+	return collectFlat(DupPermutationCount(n, k), k, func(f func([]int)) {
+		DupPermutationsWithCarrying0(n, k, f)
+	})
+}
+
+func collectFlat(count, k int, enumerate func(f func([]int))) Flat {
+	data := make([]int, count*k)
+	offset := 0
+	enumerate(func(pattern []int) {
+		copy(data[offset:], pattern)
+		offset += k
+	})
+	return Flat{data: data, k: k, count: count}
+}
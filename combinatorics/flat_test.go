@@ -0,0 +1,97 @@
+package combinatorics
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+var flatTargets = []struct {
+	family       Family
+	collect      func(n, k int) Flat
+	defaultSizes []benchSize
+}{
+	{FamilyCombinations, CollectCombinations, []benchSize{{n: 20, k: 10}, {n: 100, k: 3}}},
+	{FamilyDupCombinations, CollectDupCombinations, []benchSize{{n: 14, k: 7}, {n: 100, k: 2}}},
+	{FamilyPermutations, CollectPermutations, []benchSize{{n: 9, k: 9}, {n: 30, k: 3}}},
+	{FamilyDupPermutations, CollectDupPermutations, []benchSize{{n: 7, k: 7}, {n: 100, k: 2}}},
+}
+
+func TestFlat(t *testing.T) {
+	sizes := []benchSize{
+		{n: 0, k: 0},
+		{n: 3, k: 0},
+		{n: 3, k: 1},
+		{n: 5, k: 3},
+		{n: 4, k: 4},
+		{n: 2, k: 3},
+	}
+
+	for _, target := range flatTargets {
+		t.Run(target.family.String(), func(t *testing.T) {
+			for _, size := range sizes {
+				want := Implementations(target.family)[0].Collect(size.n, size.k)
+				flat := target.collect(size.n, size.k)
+
+				if flat.Len() != len(want) || flat.K() != size.k {
+					t.Fatalf("%v: want: len %d k %d, got: len %d k %d",
+						size, len(want), size.k, flat.Len(), flat.K())
+				}
+				if len(flat.Data()) != len(want)*size.k {
+					t.Errorf("%v: want %d numbers, got: %d", size, len(want)*size.k, len(flat.Data()))
+				}
+				got := flat.Slices()
+				if len(want) > 0 && !reflect.DeepEqual(got, want) {
+					t.Errorf("%v: want: %v, got: %v", size, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestFlatAtCapacity(t *testing.T) {
+	flat := CollectCombinations(4, 2)
+	_ = append(flat.At(0), 9)
+	if got := flat.At(1); !reflect.DeepEqual(got, []int{0, 2}) {
+		t.Errorf("the next pattern is overwritten: %v", got)
+	}
+}
+
+var flatSink Flat
+var slicesSink [][]int
+
+// BenchmarkFlat compares Flat with the implementations returning [][]int.
+func BenchmarkFlat(b *testing.B) {
+	for _, target := range flatTargets {
+		sizes, err := benchSizes(target.defaultSizes)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(target.family.String(), func(b *testing.B) {
+			for _, size := range sizes {
+				count := target.family.Count(size.n, size.k)
+
+				b.Run(fmt.Sprintf("Flat/%v", size), func(b *testing.B) {
+					benchmarkPerPattern(b, count, func() {
+						flatSink = target.collect(size.n, size.k)
+					})
+				})
+
+				for _, impl := range Implementations(target.family) {
+					signature := impl.Signature()
+					if signature != SignatureSliceReturning &&
+						signature != SignatureRangeSliceReturning &&
+						signature != SignatureABasedSliceReturning {
+						continue
+					}
+					b.Run(fmt.Sprintf("%s/%v", impl.Name, size), func(b *testing.B) {
+						benchmarkPerPattern(b, count, func() {
+							slicesSink = impl.Collect(size.n, size.k)
+						})
+					})
+				}
+			}
+		})
+	}
+}
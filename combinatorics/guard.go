@@ -0,0 +1,218 @@
+package combinatorics
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ViolationKind is a kind of misuse of a pattern by a consumer.
+type ViolationKind int
+
+const (
+	// Mutated means that the consumer modified the pattern.
+	Mutated ViolationKind = iota
+	// Retained means that the consumer kept the pattern after it returned.
+	Retained
+)
+
+func (kind ViolationKind) String() string {
+	switch kind {
+	case Mutated:
+		return "Mutated"
+	case Retained:
+		return "Retained"
+	default:
+		return "ViolationKind(?)"
+	}
+}
+
+// BufferViolation is a misuse of a pattern by a consumer.
+type BufferViolation struct {
+	Kind ViolationKind
+	// Index is the index of the pattern in the enumeration.
+	Index int
+	// Pattern is the pattern which the generator gave.
+	Pattern []int
+	// Got is the pattern after the consumer returned. It is nil for
+	// Retained.
+	Got []int
+}
+
+func (v *BufferViolation) Error() string {
+	switch v.Kind {
+	case Mutated:
+		return fmt.Sprintf("combinatorics: consumer mutated pattern #%d: %v -> %v",
+			v.Index, v.Pattern, v.Got)
+	default:
+		return fmt.Sprintf("combinatorics: consumer retained pattern #%d: %v",
+			v.Index, v.Pattern)
+	}
+}
+
+// BufferGuard checks that a consumer of a generator neither mutates nor
+// retains the patterns, which the generators reuse. It gives the consumer
+// a copy, so that the enumeration goes on correctly even if the consumer
+// mutates it.
+//
+//	guard := NewBufferGuard(consume, true)
+//	CombinationsWithCarrying0(5, 3, guard.Consume)
+//	if err := guard.Check(); err != nil {
+//		// the consumer misuses patterns
+//	}
+type BufferGuard struct {
+	f               func([]int)
+	detectRetention bool
+
+	index      int
+	view       []int // reused if it does not detect retention
+	violations []BufferViolation
+
+	// `pending` holds the patterns whose copies are not collected yet.
+	// The finalizers of the copies remove them from another goroutine.
+	mu      sync.Mutex
+	pending map[int][]int
+}
+
+// NewBufferGuard wraps the consumer `f`. If `detectRetention` is true, it
+// allocates a copy for each pattern and watches whether the garbage collector
+// frees it, which is slow and only for debugging.
+func NewBufferGuard(f func([]int), detectRetention bool) *BufferGuard {
+	return &BufferGuard{
+		f:               f,
+		detectRetention: detectRetention,
+		pending:         map[int][]int{},
+	}
+}
+
+// Consume gives a copy of the pattern to the consumer. Pass it to
+// the generator instead of the consumer.
+func (g *BufferGuard) Consume(pattern []int) {
+	index := g.index
+	g.index++
+
+	var view []int
+	if g.detectRetention && len(pattern) > 0 {
+		view = g.watchedCopy(index, pattern)
+	} else {
+		if len(g.view) != len(pattern) {
+			g.view = make([]int, len(pattern))
+		}
+		copy(g.view, pattern)
+		view = g.view
+	}
+
+	g.f(view)
+
+	if !equalInts(view, pattern) {
+		g.violations = append(g.violations, BufferViolation{
+			Kind:    Mutated,
+			Index:   index,
+			Pattern: append([]int{}, pattern...),
+			Got:     append([]int{}, view...),
+		})
+	}
+}
+
+// watchedCopy copies the pattern into a new allocation with a finalizer.
+// The capacity is at least 2 so that it is not packed with other small
+// objects by the allocator, which delays the finalizer.
+func (g *BufferGuard) watchedCopy(index int, pattern []int) []int {
+	capacity := len(pattern)
+	if capacity < 2 {
+		capacity = 2
+	}
+	view := make([]int, len(pattern), capacity)
+	copy(view, pattern)
+
+	g.mu.Lock()
+	g.pending[index] = append([]int{}, pattern...)
+	g.mu.Unlock()
+
+	runtime.SetFinalizer(&view[0], func(*int) {
+		g.mu.Lock()
+		delete(g.pending, index)
+		g.mu.Unlock()
+	})
+	return view
+}
+
+const (
+	guardRetentionTimeout = 5 * time.Second
+	// guardRetentionRounds is the number of rounds of the garbage collection
+	// in which no copy is freed before it decides that the rest is retained.
+	guardRetentionRounds = 20
+)
+
+// Check returns the first violation in the order of the patterns, or nil.
+// Call it after the enumeration.
+func (g *BufferGuard) Check() error {
+	violations := g.Violations()
+	if len(violations) == 0 {
+		return nil
+	}
+	return &violations[0]
+}
+
+// Violations returns all violations in the order of the patterns. Call it
+// after the enumeration.
+func (g *BufferGuard) Violations() []BufferViolation {
+	if g.detectRetention {
+		g.collectRetained()
+	}
+
+	sort.SliceStable(g.violations, func(i, j int) bool {
+		return g.violations[i].Index < g.violations[j].Index
+	})
+	return g.violations
+}
+
+// collectRetained runs the garbage collection until all copies are freed, or
+// until no copy is freed for a while.
+func (g *BufferGuard) collectRetained() {
+	deadline := time.Now().Add(guardRetentionTimeout)
+	last := -1
+	stableRounds := 0
+	for stableRounds < guardRetentionRounds && time.Now().Before(deadline) {
+		runtime.GC()
+		time.Sleep(time.Millisecond) // for the goroutine of finalizers
+
+		g.mu.Lock()
+		remaining := len(g.pending)
+		g.mu.Unlock()
+		if remaining == 0 {
+			break
+		}
+		if remaining == last {
+			stableRounds++
+		} else {
+			stableRounds = 0
+		}
+		last = remaining
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for index, pattern := range g.pending {
+		g.violations = append(g.violations, BufferViolation{
+			Kind:    Retained,
+			Index:   index,
+			Pattern: pattern,
+		})
+	}
+	g.pending = map[int][]int{}
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
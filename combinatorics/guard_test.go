@@ -0,0 +1,140 @@
+package combinatorics
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"reflect"
+	"runtime"
+	"testing"
+)
+
+var guardFlag = flag.Bool("guard", false,
+	"check that the consumers in tests neither mutate nor retain patterns. "+
+		"It can also be set by the environment variable "+guardEnv+"=1.")
+
+const guardEnv = "COMBINATORICS_GUARD"
+
+func guardEnabled() bool {
+	return *guardFlag || os.Getenv(guardEnv) == "1"
+}
+
+// collectPatterns is Implementation.Collect which runs the consumer under
+// BufferGuard if it is enabled.
+func collectPatterns(t testing.TB, impl Implementation, n, k int) [][]int {
+	t.Helper()
+
+	signature := impl.Signature()
+	if !guardEnabled() ||
+		(signature != SignatureCallback && signature != SignatureABasedCallback) {
+		return impl.Collect(n, k)
+	}
+
+	var guard *BufferGuard
+	got := collectInts(func(f func([]int)) {
+		guard = NewBufferGuard(f, true)
+		impl.Each(n, k, guard.Consume)
+	})
+	if err := guard.Check(); err != nil {
+		t.Fatalf("%s n=%d k=%d: %v", impl.FullName(), n, k, err)
+	}
+	return got
+}
+
+// collectInts collects clones of the patterns which `enumerate` calls back
+// with.
+func collectInts(enumerate func(f func([]int))) [][]int {
+	patterns := [][]int{}
+	enumerate(func(pattern []int) {
+		patterns = append(patterns, append([]int{}, pattern...))
+	})
+	return patterns
+}
+
+func TestBufferGuardMutation(t *testing.T) {
+	want := CombinationsRecursive0(0, 4, 2)
+
+	got := [][]int{}
+	guard := NewBufferGuard(func(pattern []int) {
+		got = append(got, append([]int{}, pattern...))
+		if len(got) == 3 {
+			pattern[1] = 0
+		}
+	}, false)
+	CombinationsWithCarrying0(4, 2, guard.Consume)
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("the enumeration is corrupted: want: %v, got: %v", want, got)
+	}
+
+	err := guard.Check()
+	var violation *BufferViolation
+	if !errors.As(err, &violation) {
+		t.Fatalf("want a violation, got: %v", err)
+	}
+	wantViolation := BufferViolation{
+		Kind:    Mutated,
+		Index:   2,
+		Pattern: []int{0, 3},
+		Got:     []int{0, 0},
+	}
+	if !reflect.DeepEqual(*violation, wantViolation) {
+		t.Errorf("want: %+v, got: %+v", wantViolation, *violation)
+	}
+	if n := len(guard.Violations()); n != 1 {
+		t.Errorf("want 1 violation, got: %d", n)
+	}
+}
+
+func TestBufferGuardRetention(t *testing.T) {
+	var retained []int
+	guard := NewBufferGuard(func(pattern []int) {
+		if pattern[0] == 1 && pattern[1] == 2 {
+			retained = pattern
+		}
+	}, true)
+	CombinationsWithCarrying0(4, 2, guard.Consume)
+
+	err := guard.Check()
+	var violation *BufferViolation
+	if !errors.As(err, &violation) {
+		t.Fatalf("want a violation, got: %v", err)
+	}
+	if violation.Kind != Retained || violation.Index != 3 ||
+		!reflect.DeepEqual(violation.Pattern, []int{1, 2}) {
+		t.Errorf("unexpected violation: %+v", *violation)
+	}
+	if n := len(guard.Violations()); n != 1 {
+		t.Errorf("want 1 violation, got: %d", n)
+	}
+	runtime.KeepAlive(retained)
+}
+
+// TestBufferGuard checks that the guard finds no violations of a consumer
+// which copies patterns, for every implementation with a callback.
+func TestBufferGuard(t *testing.T) {
+	sizes := []benchSize{{n: 0, k: 0}, {n: 3, k: 1}, {n: 4, k: 3}}
+
+	for _, impl := range AllImplementations() {
+		signature := impl.Signature()
+		if signature != SignatureCallback && signature != SignatureABasedCallback {
+			continue
+		}
+		for _, size := range sizes {
+			want := impl.Collect(size.n, size.k)
+
+			var guard *BufferGuard
+			got := collectInts(func(f func([]int)) {
+				guard = NewBufferGuard(f, true)
+				impl.Each(size.n, size.k, guard.Consume)
+			})
+
+			if err := guard.Check(); err != nil {
+				t.Errorf("%s %v: %v", impl.FullName(), size, err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("%s %v: want: %v, got: %v", impl.FullName(), size, want, got)
+			}
+		}
+	}
+}
@@ -0,0 +1,307 @@
+package combinatorics
+
+import "fmt"
+
+// prenecklaces enumerates the prenecklaces of length `k` over `n` letters
+// in lexicographic order by the FKM (Fredricksen, Kessler and Maiorana)
+// algorithm, with the length of the longest Lyndon prefix `period`.
+// The prenecklace is a necklace if `period` divides `k`, and is a Lyndon word
+// if `period` is `k`.
+func prenecklaces(n, k int, f func(pattern []int, period int)) {
+	if n < 0 || k < 0 || n == 0 && k > 0 {
+		// no patterns
+		return
+	}
+
+	// a[1:] is the prenecklace. a[0] is a sentinel to index it from 1.
+	a := make([]int, k+1)
+	pattern := a[1:]
+	if k == 0 {
+		f(pattern, 0)
+		return
+	}
+
+	f(pattern, 1)
+	for {
+		i := k
+		for i > 0 && a[i] == n-1 {
+			i--
+		}
+		if i == 0 {
+			return
+		}
+
+		a[i]++
+		for j := i + 1; j <= k; j++ {
+			a[j] = a[j-i]
+		}
+		f(pattern, i)
+	}
+}
+
+// Necklaces enumerates necklaces of length `k` over `n` letters, which are
+// the classes of patterns of DupPermutations under rotation. Each necklace
+// is represented by the lexicographically smallest pattern in its class,
+// and they are in lexicographic order. The same memory space is reused for
+// each pattern. There are no patterns for negative `n` or `k`.
+func Necklaces(n, k int, f func([]int)) {
+	prenecklaces(n, k, func(pattern []int, period int) {
+		if period == 0 || k%period == 0 {
+			f(pattern)
+		}
+	})
+}
+
+// LyndonWords enumerates Lyndon words of length `k` over `n` letters, which
+// are the necklaces different from all of their rotations, in lexicographic
+// order. There are no Lyndon words of length 0, and none for negative `n` or
+// `k`.
+func LyndonWords(n, k int, f func([]int)) {
+	prenecklaces(n, k, func(pattern []int, period int) {
+		if period == k && k > 0 {
+			f(pattern)
+		}
+	})
+}
+
+// Bracelets enumerates bracelets of length `k` over `n` letters, which are
+// the classes of patterns under rotation and reflection. Each bracelet is
+// represented by the lexicographically smallest pattern in its class, and
+// they are in lexicographic order. It filters the necklaces which are not
+// larger than the necklaces of their reversals. There are no patterns for
+// negative `n` or `k`.
+func Bracelets(n, k int, f func([]int)) {
+	if n < 0 || k < 0 {
+		// no patterns
+		return
+	}
+
+	reversed := make([]int, k)
+	rotated := make([]int, k)
+	Necklaces(n, k, func(pattern []int) {
+		for i, num := range pattern {
+			reversed[k-1-i] = num
+		}
+		if compareInts(pattern, smallestRotation(rotated, reversed)) <= 0 {
+			f(pattern)
+		}
+	})
+}
+
+// smallestRotation writes the lexicographically smallest rotation of `a` into
+// `dst`, which has the same length, and returns it.
+func smallestRotation(dst, a []int) []int {
+	k := len(a)
+	best := 0
+	for start := 1; start < k; start++ {
+		for i := 0; i < k; i++ {
+			x, y := a[(start+i)%k], a[(best+i)%k]
+			if x != y {
+				if x < y {
+					best = start
+				}
+				break
+			}
+		}
+	}
+
+	for i := range dst {
+		dst[i] = a[(best+i)%k]
+	}
+	return dst
+}
+
+func compareInts(a, b []int) int {
+	for i := range a {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// NecklaceCount computes the number of necklaces of length `k` over `n`
+// letters by Burnside's lemma, which counts the patterns fixed by each
+// rotation: (1/k) Σ_{d|k} φ(d) n^(k/d). It returns 0 for negative `n` or
+// `k`, or if the sum overflows int, which CheckedNecklaceCount reports.
+func NecklaceCount(n, k int) int {
+	count, _ := CheckedNecklaceCount(n, k)
+	return count
+}
+
+// CheckedNecklaceCount is NecklaceCount which returns an error wrapping
+// ErrNegative for negative `n` or `k`, or ErrCountOverflow if the sum
+// overflows int, that is, if `k` times the number does.
+func CheckedNecklaceCount(n, k int) (int, error) {
+	if err := validateNecklaceSize("necklaces", n, k); err != nil {
+		return 0, err
+	}
+	if k == 0 {
+		return 1, nil
+	}
+	sum, ok := necklaceSum(n, k)
+	if !ok {
+		return 0, necklaceOverflow("necklaces", n, k)
+	}
+	return sum / k, nil
+}
+
+// necklaceSum computes Σ_{d|k} φ(d) n^(k/d) for positive `k`.
+func necklaceSum(n, k int) (int, bool) {
+	sum := 0
+	for d := 1; d <= k; d++ {
+		if k%d != 0 {
+			continue
+		}
+		fixed, ok := checkedDupPermutationCount(n, k/d)
+		if ok {
+			fixed, ok = checkedMul(totient(d), fixed)
+		}
+		if ok {
+			sum, ok = checkedAdd(sum, fixed)
+		}
+		if !ok {
+			return 0, false
+		}
+	}
+	return sum, true
+}
+
+// LyndonWordCount computes the number of Lyndon words of length `k` over `n`
+// letters by Möbius inversion: (1/k) Σ_{d|k} μ(d) n^(k/d). It returns 0 for
+// negative `n` or `k`, or if a term overflows int, which
+// CheckedLyndonWordCount reports.
+func LyndonWordCount(n, k int) int {
+	count, _ := CheckedLyndonWordCount(n, k)
+	return count
+}
+
+// CheckedLyndonWordCount is LyndonWordCount which returns an error wrapping
+// ErrNegative for negative `n` or `k`, or ErrCountOverflow if a term or the
+// sum of the positive terms overflows int.
+func CheckedLyndonWordCount(n, k int) (int, error) {
+	if err := validateNecklaceSize("Lyndon words", n, k); err != nil {
+		return 0, err
+	}
+	if k == 0 {
+		return 0, nil
+	}
+	sum := 0
+	for d := 1; d <= k; d++ {
+		if k%d != 0 || mobius(d) == 0 {
+			continue
+		}
+		term, ok := checkedDupPermutationCount(n, k/d)
+		if ok && mobius(d) < 0 {
+			sum -= term
+		} else if ok {
+			sum, ok = checkedAdd(sum, term)
+		}
+		if !ok {
+			return 0, necklaceOverflow("Lyndon words", n, k)
+		}
+	}
+	return sum / k, nil
+}
+
+// BraceletCount computes the number of bracelets of length `k` over `n`
+// letters by Burnside's lemma over the dihedral group, whose reflections
+// fix n^((k+1)/2) patterns for odd `k`, and n^(k/2) or n^(k/2+1) patterns
+// for even `k`. It returns 0 for negative `n` or `k`, or if the sum overflows
+// int, which CheckedBraceletCount reports.
+func BraceletCount(n, k int) int {
+	count, _ := CheckedBraceletCount(n, k)
+	return count
+}
+
+// CheckedBraceletCount is BraceletCount which returns an error wrapping
+// ErrNegative for negative `n` or `k`, or ErrCountOverflow if the sum
+// overflows int, that is, if `2k` times the number does.
+func CheckedBraceletCount(n, k int) (int, error) {
+	if err := validateNecklaceSize("bracelets", n, k); err != nil {
+		return 0, err
+	}
+	if k == 0 {
+		return 1, nil
+	}
+	sum, ok := necklaceSum(n, k)
+
+	// the patterns fixed by the reflections, summed up
+	var reflected int
+	if ok && k%2 == 1 {
+		reflected, ok = checkedDupPermutationCount(n, (k+1)/2)
+		if ok {
+			reflected, ok = checkedMul(k, reflected)
+		}
+	} else if ok {
+		var short, long int
+		short, ok = checkedDupPermutationCount(n, k/2)
+		if ok {
+			long, ok = checkedDupPermutationCount(n, k/2+1)
+		}
+		if ok {
+			reflected, ok = checkedAdd(short, long)
+		}
+		if ok {
+			reflected, ok = checkedMul(k/2, reflected)
+		}
+	}
+	if ok {
+		sum, ok = checkedAdd(sum, reflected)
+	}
+	if !ok {
+		return 0, necklaceOverflow("bracelets", n, k)
+	}
+	return sum / (2 * k), nil
+}
+
+func validateNecklaceSize(kind string, n, k int) error {
+	if n < 0 || k < 0 {
+		return fmt.Errorf("combinatorics: %s of n=%d, k=%d: %w", kind, n, k, ErrNegative)
+	}
+	return nil
+}
+
+func necklaceOverflow(kind string, n, k int) error {
+	return fmt.Errorf("combinatorics: %s of n=%d, k=%d: %w", kind, n, k, ErrCountOverflow)
+}
+
+// totient computes Euler's totient function φ(d).
+func totient(d int) int {
+	ans := d
+	for p := 2; p*p <= d; p++ {
+		if d%p != 0 {
+			continue
+		}
+		for d%p == 0 {
+			d /= p
+		}
+		ans -= ans / p
+	}
+	if d > 1 {
+		ans -= ans / d
+	}
+	return ans
+}
+
+// mobius computes the Möbius function μ(d).
+func mobius(d int) int {
+	ans := 1
+	for p := 2; p*p <= d; p++ {
+		if d%p != 0 {
+			continue
+		}
+		d /= p
+		if d%p == 0 {
+			return 0
+		}
+		ans = -ans
+	}
+	if d > 1 {
+		ans = -ans
+	}
+	return ans
+}
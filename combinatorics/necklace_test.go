@@ -0,0 +1,166 @@
+package combinatorics
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// bruteForceClasses canonicalizes all patterns of DupPermutationsWithCarrying0
+// into the lexicographically smallest patterns in their classes under
+// rotation, and under reflection as well if `withReflection` is true. The
+// patterns of the classes whose members are all distinct are also returned
+// as `aperiodic`.
+func bruteForceClasses(n, k int, withReflection bool) (classes, aperiodic [][]int) {
+	classes = [][]int{}
+	aperiodic = [][]int{}
+	DupPermutationsWithCarrying0(n, k, func(pattern []int) {
+		smallest := true
+		distinct := true
+		candidates := [][]int{pattern}
+		if withReflection {
+			reversed := make([]int, k)
+			for i, num := range pattern {
+				reversed[k-1-i] = num
+			}
+			candidates = append(candidates, reversed)
+		}
+		for c, candidate := range candidates {
+			for shift := 0; shift < k; shift++ {
+				if c == 0 && shift == 0 {
+					continue
+				}
+				rotated := append(append([]int{}, candidate[shift:]...), candidate[:shift]...)
+				switch compareInts(rotated, pattern) {
+				case -1:
+					smallest = false
+				case 0:
+					distinct = false
+				}
+			}
+		}
+		if smallest {
+			classes = append(classes, append([]int{}, pattern...))
+			if distinct && k > 0 {
+				aperiodic = append(aperiodic, append([]int{}, pattern...))
+			}
+		}
+	})
+	return classes, aperiodic
+}
+
+func TestNecklaces(t *testing.T) {
+	for n := 0; n <= 4; n++ {
+		for k := 0; k <= 6; k++ {
+			name := fmt.Sprintf("n=%d,k=%d", n, k)
+			necklaces, lyndonWords := bruteForceClasses(n, k, false)
+			bracelets, _ := bruteForceClasses(n, k, true)
+
+			cases := []struct {
+				kind  string
+				got   [][]int
+				want  [][]int
+				count int
+			}{
+				{"Necklaces", collectInts(func(f func([]int)) { Necklaces(n, k, f) }),
+					necklaces, NecklaceCount(n, k)},
+				{"LyndonWords", collectInts(func(f func([]int)) { LyndonWords(n, k, f) }),
+					lyndonWords, LyndonWordCount(n, k)},
+				{"Bracelets", collectInts(func(f func([]int)) { Bracelets(n, k, f) }),
+					bracelets, BraceletCount(n, k)},
+			}
+			for _, c := range cases {
+				if !reflect.DeepEqual(c.got, c.want) {
+					t.Errorf("%s %s: want: %v, got: %v", c.kind, name, c.want, c.got)
+				}
+				if c.count != len(c.want) {
+					t.Errorf("%s %s: the count: want: %d, got: %d", c.kind, name, len(c.want), c.count)
+				}
+			}
+		}
+	}
+}
+
+func TestNecklacesNegative(t *testing.T) {
+	enumerators := map[string]func(n, k int, f func([]int)){
+		"Necklaces":   Necklaces,
+		"LyndonWords": LyndonWords,
+		"Bracelets":   Bracelets,
+	}
+	for kind, enumerate := range enumerators {
+		for _, size := range []struct{ n, k int }{{-1, 3}, {2, -1}, {-1, -1}} {
+			got := collectInts(func(f func([]int)) { enumerate(size.n, size.k, f) })
+			if len(got) != 0 {
+				t.Errorf("%s n=%d,k=%d: want no patterns, got %v", kind, size.n, size.k, got)
+			}
+		}
+	}
+}
+
+func TestNecklaceCounts(t *testing.T) {
+	// OEIS A000031, A001037 and A000029 for 2 letters
+	necklaces := []int{1, 2, 3, 4, 6, 8, 14, 20, 36, 60, 108}
+	lyndonWords := []int{0, 2, 1, 2, 3, 6, 9, 18, 30, 56, 99}
+	bracelets := []int{1, 2, 3, 4, 6, 8, 13, 18, 30, 46, 78}
+	for k := range necklaces {
+		if got := NecklaceCount(2, k); got != necklaces[k] {
+			t.Errorf("NecklaceCount(2, %d): want: %d, got: %d", k, necklaces[k], got)
+		}
+		if got := LyndonWordCount(2, k); got != lyndonWords[k] {
+			t.Errorf("LyndonWordCount(2, %d): want: %d, got: %d", k, lyndonWords[k], got)
+		}
+		if got := BraceletCount(2, k); got != bracelets[k] {
+			t.Errorf("BraceletCount(2, %d): want: %d, got: %d", k, bracelets[k], got)
+		}
+	}
+}
+
+func TestCheckedNecklaceCounts(t *testing.T) {
+	counts := map[string]func(n, k int) (int, error){
+		"CheckedNecklaceCount":   CheckedNecklaceCount,
+		"CheckedLyndonWordCount": CheckedLyndonWordCount,
+		"CheckedBraceletCount":   CheckedBraceletCount,
+	}
+	cases := []struct {
+		kind    string
+		n, k    int
+		want    int
+		wantErr error
+	}{
+		{"CheckedNecklaceCount", 2, 62, 74382032589917286, nil},
+		{"CheckedLyndonWordCount", 2, 62, 74382032520643617, nil},
+		{"CheckedBraceletCount", 2, 62, 37191017905571379, nil},
+		// the sums exceed 2^63
+		{"CheckedNecklaceCount", 2, 63, 0, ErrCountOverflow},
+		{"CheckedLyndonWordCount", 2, 63, 0, ErrCountOverflow},
+		{"CheckedBraceletCount", 2, 63, 0, ErrCountOverflow},
+		{"CheckedNecklaceCount", 2, 70, 0, ErrCountOverflow},
+		{"CheckedNecklaceCount", -1, 3, 0, ErrNegative},
+		{"CheckedLyndonWordCount", 2, -1, 0, ErrNegative},
+		{"CheckedBraceletCount", -1, -1, 0, ErrNegative},
+	}
+	for _, c := range cases {
+		got, err := counts[c.kind](c.n, c.k)
+		if !errors.Is(err, c.wantErr) {
+			t.Errorf("%s(%d, %d): want the error %v, got %v", c.kind, c.n, c.k, c.wantErr, err)
+		}
+		if got != c.want {
+			t.Errorf("%s(%d, %d): want: %d, got: %d", c.kind, c.n, c.k, c.want, got)
+		}
+	}
+
+	// the unchecked one returns 0 instead of a wrapped-around number
+	if got := NecklaceCount(2, 70); got != 0 {
+		t.Errorf("NecklaceCount(2, 70): got %d", got)
+	}
+}
+
+func TestBraceletsAllocs(t *testing.T) {
+	// the buffers are allocated once, not for each necklace
+	few := testing.AllocsPerRun(10, func() { Bracelets(2, 8, func([]int) {}) })
+	many := testing.AllocsPerRun(10, func() { Bracelets(4, 8, func([]int) {}) })
+	if few != many {
+		t.Errorf("the allocations grow with the necklaces: %v for n=2, %v for n=4", few, many)
+	}
+}
@@ -0,0 +1,379 @@
+package combinatorics
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+type nextTarget struct {
+	name string
+	all  func(n, k int) [][]int
+	next func(n int, pattern []int) bool
+	prev func(n int, pattern []int) bool
+}
+
+var nextTargets = []nextTarget{
+	{"Combination",
+		func(n, k int) [][]int {
+			return CombinationsRecursive0(0, n, k)
+		},
+		NextCombination, PrevCombination},
+	{"DupCombination",
+		func(n, k int) [][]int {
+			return DupCombinationsRecursive0(0, n, k)
+		},
+		NextDupCombination, PrevDupCombination},
+	{"DupPermutation",
+		DupPermutationsRecursive0,
+		NextDupPermutation, PrevDupPermutation},
+	{"PartialPermutation",
+		func(n, k int) [][]int {
+			return PermutationsRecursive0(numbers(n), k)
+		},
+		func(n int, pattern []int) bool {
+			return NextPartialPermutation(n, pattern, checklistOf(n, pattern))
+		},
+		func(n int, pattern []int) bool {
+			return PrevPartialPermutation(n, pattern, checklistOf(n, pattern))
+		}},
+	{"Permutation",
+		func(n, k int) [][]int {
+			if k != n {
+				return nil
+			}
+			return PermutationsRecursive0(numbers(n), k)
+		},
+		func(n int, pattern []int) bool {
+			return NextPermutation(pattern)
+		},
+		func(n int, pattern []int) bool {
+			return PrevPermutation(pattern)
+		}},
+}
+
+func TestNextAndPrev(t *testing.T) {
+	cases := []struct {
+		n, k int
+	}{
+		{n: 0, k: 0},
+		{n: 3, k: 0},
+		{n: 3, k: 1},
+		{n: 4, k: 4},
+		{n: 5, k: 3},
+	}
+
+	for _, target := range nextTargets {
+		t.Run(target.name, func(t *testing.T) {
+			for _, c := range cases {
+				want := target.all(c.n, c.k)
+				if want == nil {
+					continue
+				}
+
+				t.Run(fmt.Sprintf("Next n=%d k=%d", c.n, c.k), func(t *testing.T) {
+					pattern := append([]int{}, want[0]...)
+					for i := 1; i < len(want); i++ {
+						if !target.next(c.n, pattern) {
+							t.Fatalf("returned false at %v", want[i-1])
+						}
+						if !reflect.DeepEqual(pattern, want[i]) {
+							t.Fatalf("next of %v: want: %v, got: %v", want[i-1], want[i], pattern)
+						}
+					}
+					if target.next(c.n, pattern) {
+						t.Errorf("returned true at the last: %v", pattern)
+					}
+					if !reflect.DeepEqual(pattern, want[0]) {
+						t.Errorf("not reset: want: %v, got: %v", want[0], pattern)
+					}
+				})
+
+				t.Run(fmt.Sprintf("Prev n=%d k=%d", c.n, c.k), func(t *testing.T) {
+					last := len(want) - 1
+					pattern := append([]int{}, want[last]...)
+					for i := last - 1; i >= 0; i-- {
+						if !target.prev(c.n, pattern) {
+							t.Fatalf("returned false at %v", want[i+1])
+						}
+						if !reflect.DeepEqual(pattern, want[i]) {
+							t.Fatalf("prev of %v: want: %v, got: %v", want[i+1], want[i], pattern)
+						}
+					}
+					if target.prev(c.n, pattern) {
+						t.Errorf("returned true at the first: %v", pattern)
+					}
+					if !reflect.DeepEqual(pattern, want[last]) {
+						t.Errorf("not reset: want: %v, got: %v", want[last], pattern)
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestPartialPermutationChecklist(t *testing.T) {
+	const n = 5
+	const k = 3
+
+	pattern := []int{0, 1, 2}
+	checklist := checklistOf(n, pattern)
+	for {
+		if want := checklistOf(n, pattern); !reflect.DeepEqual(checklist, want) {
+			t.Fatalf("checklist of %v: want: %v, got: %v", pattern, want, checklist)
+		}
+		if !NextPartialPermutation(n, pattern, checklist) {
+			break
+		}
+	}
+	for {
+		if !PrevPartialPermutation(n, pattern, checklist) {
+			break
+		}
+		if want := checklistOf(n, pattern); !reflect.DeepEqual(checklist, want) {
+			t.Fatalf("checklist of %v: want: %v, got: %v", pattern, want, checklist)
+		}
+	}
+}
+
+func BenchmarkNext(b *testing.B) {
+	targets := []struct {
+		name  string
+		count int
+		f     func()
+	}{
+		// the same sizes as BenchmarkCombinations and so on
+		{"Combination", CombinationCount(24, 12),
+			func() {
+				pattern := numbers(12)
+				for {
+					doSomethingForPattern(pattern)
+					if !NextCombination(24, pattern) {
+						break
+					}
+				}
+			}},
+		{"DupCombination", DupCombinationCount(18, 9),
+			func() {
+				pattern := make([]int, 9)
+				for {
+					doSomethingForPattern(pattern)
+					if !NextDupCombination(18, pattern) {
+						break
+					}
+				}
+			}},
+		{"DupPermutation", DupPermutationCount(8, 7),
+			func() {
+				pattern := make([]int, 7)
+				for {
+					doSomethingForPattern(pattern)
+					if !NextDupPermutation(8, pattern) {
+						break
+					}
+				}
+			}},
+		{"PartialPermutation", PermutationCount(10, 10),
+			func() {
+				pattern := numbers(10)
+				checklist := checklistOf(10, pattern)
+				for {
+					doSomethingForPattern(pattern)
+					if !NextPartialPermutation(10, pattern, checklist) {
+						break
+					}
+				}
+			}},
+		{"Permutation", PermutationCount(10, 10),
+			func() {
+				pattern := numbers(10)
+				for {
+					doSomethingForPattern(pattern)
+					if !NextPermutation(pattern) {
+						break
+					}
+				}
+			}},
+		// the carrying loops before they were extracted, as the baselines
+		{"CombinationInline", CombinationCount(24, 12),
+			func() { inlineCombinations(24, 12, doSomethingForPattern) }},
+		{"DupCombinationInline", DupCombinationCount(18, 9),
+			func() { inlineDupCombinations(18, 9, doSomethingForPattern) }},
+		{"DupPermutationInline", DupPermutationCount(8, 7),
+			func() { inlineDupPermutations(8, 7, doSomethingForPattern) }},
+		{"PartialPermutationInline", PermutationCount(10, 10),
+			func() { inlinePartialPermutations(10, 10, doSomethingForPattern) }},
+	}
+
+	for _, target := range targets {
+		b.Run(target.name, func(b *testing.B) {
+			benchmarkPerPattern(b, target.count, target.f)
+		})
+	}
+}
+
+// inlineCombinations is CombinationsWithCarrying0 before NextCombination was
+// extracted from it.
+func inlineCombinations(n, k int, f func([]int)) {
+	pattern := numbers(k)
+
+	for {
+		f(pattern)
+
+		pos := k - 1
+		for {
+			if pos == -1 {
+				return
+			}
+
+			oldNum := pattern[pos]
+			if oldNum == n+pos-k {
+				// carry
+				pos--
+				continue
+			}
+
+			// increment
+			pattern[pos]++
+			break
+		}
+
+		// replace the numbers of carried digits
+		for pos++; pos < k; pos++ {
+			pattern[pos] = pattern[pos-1] + 1
+		}
+	}
+}
+
+// inlineDupCombinations is DupCombinationsWithCarrying0 before
+// NextDupCombination was extracted from it.
+func inlineDupCombinations(n, k int, f func([]int)) {
+	pattern := make([]int, k)
+
+	for {
+		f(pattern)
+
+		pos := k - 1
+		for {
+			if pos == -1 {
+				return
+			}
+
+			oldNum := pattern[pos]
+			if oldNum == n-1 {
+				// carry
+				pos--
+				continue
+			}
+
+			// increment
+			pattern[pos]++
+			break
+		}
+
+		// replace the numbers of carried digits
+		numToReplace := pattern[pos]
+		for pos++; pos < k; pos++ {
+			pattern[pos] = numToReplace
+		}
+	}
+}
+
+// inlineDupPermutations is DupPermutationsWithCarrying0 before
+// NextDupPermutation was extracted from it.
+func inlineDupPermutations(n, k int, f func([]int)) {
+	pattern := make([]int, k)
+
+	for {
+		f(pattern)
+
+		pos := k - 1
+		for {
+			if pos == -1 {
+				return
+			}
+
+			oldNum := pattern[pos]
+			if oldNum == n-1 {
+				// carry
+				pattern[pos] = 0
+				pos--
+				continue
+			}
+
+			// increment
+			pattern[pos]++
+			break
+		}
+	}
+}
+
+// inlinePartialPermutations is PermutationsWithCarrying1 before
+// NextPartialPermutation was extracted from it.
+func inlinePartialPermutations(n, k int, f func([]int)) {
+	checklist := make([]bool, n)
+	pattern := make([]int, k)
+	for i := range pattern {
+		pattern[i] = i
+		checklist[i] = true
+	}
+
+	for {
+		f(pattern)
+
+		// increment
+		pos := k - 1 // current digit
+		for {
+			if pos == -1 {
+				return
+			}
+
+			oldNum := pattern[pos]
+			checklist[oldNum] = false
+
+			willBreak := false
+			for newNum := oldNum + 1; newNum < n; newNum++ {
+				// skip if the number of `newNum` is used
+				if checklist[newNum] {
+					continue
+				}
+
+				// increment the value of the current digit
+				pattern[pos] = newNum
+				checklist[newNum] = true
+				willBreak = true
+				break
+			}
+			if willBreak {
+				break
+			}
+
+			// the case it cannot increment the current digit
+			// -> carry
+			pos--
+		}
+
+		// replace the numbers of carried digits
+		for pos++; pos < k; pos++ {
+			for num := 0; num < k; num++ {
+				// skip if the number of `num` is used
+				if checklist[num] {
+					continue
+				}
+
+				// replace
+				pattern[pos] = num
+				checklist[num] = true
+				break
+			}
+		}
+	}
+}
+
+func checklistOf(n int, pattern []int) []bool {
+	checklist := make([]bool, n)
+	for _, num := range pattern {
+		checklist[num] = true
+	}
+	return checklist
+}
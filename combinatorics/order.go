@@ -0,0 +1,383 @@
+package combinatorics
+
+// Order is an order to enumerate patterns in.
+type Order int
+
+const (
+	// Lex is the lexicographic order, which the generators use.
+	Lex Order = iota
+	// RevLex is the reverse of the lexicographic order.
+	RevLex
+	// Colex is the colexicographic order, which compares patterns from
+	// the most right digit.
+	Colex
+	// RevColex is the reverse of the colexicographic order.
+	RevColex
+)
+
+func (order Order) String() string {
+	switch order {
+	case Lex:
+		return "Lex"
+	case RevLex:
+		return "RevLex"
+	case Colex:
+		return "Colex"
+	case RevColex:
+		return "RevColex"
+	default:
+		return "Order(?)"
+	}
+}
+
+// CombinationsInOrder enumerates combinations in the order.
+func CombinationsInOrder(n, k int, order Order, f func([]int)) {
+	each(NewCombinationIterator(n, k, order), f)
+}
+
+// NewCombinationIterator creates an iterator of combinations in the order.
+func NewCombinationIterator(n, k int, order Order) *Iterator {
+	return newIterator(combinationLexSpace(n, k), k, order)
+}
+
+// CombinationRankInOrder computes the index of the combination in the order.
+func CombinationRankInOrder(n int, pattern []int, order Order) int {
+	return rankInOrder(combinationLexSpace(n, len(pattern)), pattern, order)
+}
+
+// CombinationUnrankInOrder fills `pattern` with the combination of the index
+// in the order. It is the inverse of CombinationRankInOrder.
+func CombinationUnrankInOrder(n, rank int, pattern []int, order Order) {
+	unrankInOrder(combinationLexSpace(n, len(pattern)), rank, pattern, order)
+}
+
+// DupCombinationsInOrder enumerates combinations with repetition in
+// the order.
+func DupCombinationsInOrder(n, k int, order Order, f func([]int)) {
+	each(NewDupCombinationIterator(n, k, order), f)
+}
+
+// NewDupCombinationIterator creates an iterator of combinations with
+// repetition in the order.
+func NewDupCombinationIterator(n, k int, order Order) *Iterator {
+	return newIterator(dupCombinationLexSpace(n, k), k, order)
+}
+
+// DupCombinationRankInOrder computes the index of the combination with
+// repetition in the order.
+func DupCombinationRankInOrder(n int, pattern []int, order Order) int {
+	return rankInOrder(dupCombinationLexSpace(n, len(pattern)), pattern, order)
+}
+
+// DupCombinationUnrankInOrder fills `pattern` with the combination with
+// repetition of the index in the order. It is the inverse of
+// DupCombinationRankInOrder.
+func DupCombinationUnrankInOrder(n, rank int, pattern []int, order Order) {
+	unrankInOrder(dupCombinationLexSpace(n, len(pattern)), rank, pattern, order)
+}
+
+// PermutationsInOrder enumerates permutations in the order.
+func PermutationsInOrder(n, k int, order Order, f func([]int)) {
+	each(NewPermutationIterator(n, k, order), f)
+}
+
+// NewPermutationIterator creates an iterator of permutations in the order.
+func NewPermutationIterator(n, k int, order Order) *Iterator {
+	return newIterator(permutationLexSpace(n, k), k, order)
+}
+
+// PermutationRankInOrder computes the index of the permutation in the order.
+func PermutationRankInOrder(n int, pattern []int, order Order) int {
+	return rankInOrder(permutationLexSpace(n, len(pattern)), pattern, order)
+}
+
+// PermutationUnrankInOrder fills `pattern` with the permutation of the index
+// in the order. It is the inverse of PermutationRankInOrder.
+func PermutationUnrankInOrder(n, rank int, pattern []int, order Order) {
+	unrankInOrder(permutationLexSpace(n, len(pattern)), rank, pattern, order)
+}
+
+// DupPermutationsInOrder enumerates permutations with repetition in
+// the order.
+func DupPermutationsInOrder(n, k int, order Order, f func([]int)) {
+	each(NewDupPermutationIterator(n, k, order), f)
+}
+
+// NewDupPermutationIterator creates an iterator of permutations with
+// repetition in the order.
+func NewDupPermutationIterator(n, k int, order Order) *Iterator {
+	return newIterator(dupPermutationLexSpace(n, k), k, order)
+}
+
+// DupPermutationRankInOrder computes the index of the permutation with
+// repetition in the order.
+func DupPermutationRankInOrder(n int, pattern []int, order Order) int {
+	return rankInOrder(dupPermutationLexSpace(n, len(pattern)), pattern, order)
+}
+
+// DupPermutationUnrankInOrder fills `pattern` with the permutation with
+// repetition of the index in the order. It is the inverse of
+// DupPermutationRankInOrder.
+func DupPermutationUnrankInOrder(n, rank int, pattern []int, order Order) {
+	unrankInOrder(dupPermutationLexSpace(n, len(pattern)), rank, pattern, order)
+}
+
+// Iterator enumerates patterns one by one.
+//
+//	it := NewCombinationIterator(5, 3, Colex)
+//	for it.Next() {
+//		fmt.Println(it.Pattern())
+//	}
+type Iterator struct {
+	space   *lexSpace
+	forward bool // whether it goes forward in lexicographic order
+
+	// `lexPattern` goes in lexicographic order. `pattern` is transformed from
+	// `lexPattern` for colexicographic orders, or is `lexPattern` itself.
+	lexPattern  []int
+	pattern     []int
+	transformed bool
+
+	rank     int // the index of `pattern`
+	nextRank int
+	loaded   bool // whether `lexPattern` is of `rank`
+}
+
+func newIterator(space *lexSpace, k int, order Order) *Iterator {
+	forward, transformed := space.direction(order)
+
+	it := Iterator{
+		space:       space,
+		forward:     forward,
+		lexPattern:  make([]int, k),
+		transformed: transformed,
+		rank:        -1,
+	}
+	it.pattern = it.lexPattern
+	if transformed {
+		it.pattern = make([]int, k)
+	}
+	return &it
+}
+
+// Next moves to the next pattern. It returns false if there is no more
+// pattern.
+func (it *Iterator) Next() bool {
+	if it.nextRank >= it.space.count {
+		it.loaded = false
+		return false
+	}
+
+	if it.loaded && it.nextRank == it.rank+1 {
+		if it.forward {
+			it.space.next(it.lexPattern)
+		} else {
+			it.space.prev(it.lexPattern)
+		}
+	} else {
+		lexRank := it.nextRank
+		if !it.forward {
+			lexRank = it.space.count - 1 - lexRank
+		}
+		it.space.load(lexRank, it.lexPattern)
+	}
+	it.rank = it.nextRank
+	it.nextRank++
+	it.loaded = true
+
+	if it.transformed {
+		copy(it.pattern, it.lexPattern)
+		it.space.transform(it.pattern)
+	}
+	return true
+}
+
+// Pattern returns the current pattern. The iterator reuses the same memory
+// space for each pattern.
+func (it *Iterator) Pattern() []int {
+	return it.pattern
+}
+
+// Rank returns the index of the current pattern in the order.
+func (it *Iterator) Rank() int {
+	return it.rank
+}
+
+// Seek sets the iterator so that the next call of Next moves to the pattern
+// of the index. A negative index is clamped to 0, and an index beyond the
+// last pattern makes Next return false.
+func (it *Iterator) Seek(rank int) {
+	if rank < 0 {
+		rank = 0
+	}
+	it.nextRank = rank
+	it.loaded = false
+}
+
+func each(it *Iterator, f func([]int)) {
+	for it.Next() {
+		f(it.Pattern())
+	}
+}
+
+func rankInOrder(space *lexSpace, pattern []int, order Order) int {
+	forward, transformed := space.direction(order)
+
+	lexPattern := pattern
+	if transformed {
+		lexPattern = append([]int{}, pattern...)
+		space.transform(lexPattern)
+	}
+
+	lexRank := space.rank(lexPattern)
+	if !forward {
+		return space.count - 1 - lexRank
+	}
+	return lexRank
+}
+
+func unrankInOrder(space *lexSpace, rank int, pattern []int, order Order) {
+	forward, transformed := space.direction(order)
+
+	lexRank := rank
+	if !forward {
+		lexRank = space.count - 1 - rank
+	}
+	space.load(lexRank, pattern)
+
+	if transformed {
+		space.transform(pattern)
+	}
+}
+
+// lexSpace describes patterns of a family in lexicographic order.
+// Colexicographic order is reduced to lexicographic order by `transform`,
+// which is an involution. If `transformReverses` is true, `transform`
+// reverses the order.
+type lexSpace struct {
+	count             int
+	load              func(rank int, pattern []int)
+	rank              func(pattern []int) int
+	next              func(pattern []int) bool
+	prev              func(pattern []int) bool
+	transform         func(pattern []int)
+	transformReverses bool
+}
+
+// direction decides whether it goes forward in lexicographic order and
+// whether it transforms patterns to enumerate in the order.
+func (space *lexSpace) direction(order Order) (forward, transformed bool) {
+	switch order {
+	case RevLex:
+		return false, false
+	case Colex:
+		return !space.transformReverses, true
+	case RevColex:
+		return space.transformReverses, true
+	default:
+		return true, false
+	}
+}
+
+// combinationLexSpace uses the transform which reverses digits and
+// complements each number, e.g. [0, 1, 3] -> [1, 3, 4] for `n = 5`.
+func combinationLexSpace(n, k int) *lexSpace {
+	return &lexSpace{
+		count: CombinationCount(n, k),
+		load: func(rank int, pattern []int) {
+			CombinationUnrank(n, rank, pattern)
+		},
+		rank: func(pattern []int) int {
+			return CombinationRank(n, pattern)
+		},
+		next: func(pattern []int) bool {
+			return NextCombination(n, pattern)
+		},
+		prev: func(pattern []int) bool {
+			return PrevCombination(n, pattern)
+		},
+		transform: func(pattern []int) {
+			complementReverse(n, pattern)
+		},
+		transformReverses: true,
+	}
+}
+
+func dupCombinationLexSpace(n, k int) *lexSpace {
+	return &lexSpace{
+		count: DupCombinationCount(n, k),
+		load: func(rank int, pattern []int) {
+			DupCombinationUnrank(n, rank, pattern)
+		},
+		rank: func(pattern []int) int {
+			return DupCombinationRank(n, pattern)
+		},
+		next: func(pattern []int) bool {
+			return NextDupCombination(n, pattern)
+		},
+		prev: func(pattern []int) bool {
+			return PrevDupCombination(n, pattern)
+		},
+		transform: func(pattern []int) {
+			complementReverse(n, pattern)
+		},
+		transformReverses: true,
+	}
+}
+
+// permutationLexSpace uses the transform which reverses digits.
+func permutationLexSpace(n, k int) *lexSpace {
+	checklist := make([]bool, n)
+
+	return &lexSpace{
+		count: PermutationCount(n, k),
+		load: func(rank int, pattern []int) {
+			PermutationUnrank(n, rank, pattern)
+
+			for i := range checklist {
+				checklist[i] = false
+			}
+			for _, num := range pattern {
+				checklist[num] = true
+			}
+		},
+		rank: func(pattern []int) int {
+			return PermutationRank(n, pattern)
+		},
+		next: func(pattern []int) bool {
+			return NextPartialPermutation(n, pattern, checklist)
+		},
+		prev: func(pattern []int) bool {
+			return PrevPartialPermutation(n, pattern, checklist)
+		},
+		transform:         reverseInts,
+		transformReverses: false,
+	}
+}
+
+func dupPermutationLexSpace(n, k int) *lexSpace {
+	return &lexSpace{
+		count: DupPermutationCount(n, k),
+		load: func(rank int, pattern []int) {
+			DupPermutationUnrank(n, rank, pattern)
+		},
+		rank: func(pattern []int) int {
+			return DupPermutationRank(n, pattern)
+		},
+		next: func(pattern []int) bool {
+			return NextDupPermutation(n, pattern)
+		},
+		prev: func(pattern []int) bool {
+			return PrevDupPermutation(n, pattern)
+		},
+		transform:         reverseInts,
+		transformReverses: false,
+	}
+}
+
+func complementReverse(n int, pattern []int) {
+	reverseInts(pattern)
+	for i := range pattern {
+		pattern[i] = n - 1 - pattern[i]
+	}
+}
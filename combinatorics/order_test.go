@@ -0,0 +1,155 @@
+package combinatorics
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestOrders(t *testing.T) {
+	targets := []struct {
+		name     string
+		all      func(n, k int) [][]int
+		inOrder  func(n, k int, order Order, f func([]int))
+		iterator func(n, k int, order Order) *Iterator
+		rank     func(n int, pattern []int, order Order) int
+		unrank   func(n, rank int, pattern []int, order Order)
+	}{
+		{"Combinations",
+			func(n, k int) [][]int {
+				return CombinationsRecursive0(0, n, k)
+			},
+			CombinationsInOrder, NewCombinationIterator,
+			CombinationRankInOrder, CombinationUnrankInOrder},
+		{"DupCombinations",
+			func(n, k int) [][]int {
+				return DupCombinationsRecursive0(0, n, k)
+			},
+			DupCombinationsInOrder, NewDupCombinationIterator,
+			DupCombinationRankInOrder, DupCombinationUnrankInOrder},
+		{"Permutations",
+			func(n, k int) [][]int {
+				return PermutationsRecursive0(numbers(n), k)
+			},
+			PermutationsInOrder, NewPermutationIterator,
+			PermutationRankInOrder, PermutationUnrankInOrder},
+		{"DupPermutations",
+			DupPermutationsRecursive0,
+			DupPermutationsInOrder, NewDupPermutationIterator,
+			DupPermutationRankInOrder, DupPermutationUnrankInOrder},
+	}
+
+	orders := []struct {
+		order Order
+		less  func(a, b []int) bool
+	}{
+		{Lex, lexLess},
+		{RevLex, func(a, b []int) bool { return lexLess(b, a) }},
+		{Colex, colexLess},
+		{RevColex, func(a, b []int) bool { return colexLess(b, a) }},
+	}
+
+	cases := []struct {
+		n, k int
+	}{
+		{n: 0, k: 0},
+		{n: 3, k: 0},
+		{n: 3, k: 1},
+		{n: 4, k: 4},
+		{n: 5, k: 3},
+	}
+
+	for _, target := range targets {
+		t.Run(target.name, func(t *testing.T) {
+			for _, o := range orders {
+				for _, c := range cases {
+					t.Run(fmt.Sprintf("%v n=%d k=%d", o.order, c.n, c.k), func(t *testing.T) {
+						want := target.all(c.n, c.k)
+						sort.SliceStable(want, func(i, j int) bool {
+							return o.less(want[i], want[j])
+						})
+
+						got := [][]int{}
+						target.inOrder(c.n, c.k, o.order, func(pattern []int) {
+							patternClone := make([]int, len(pattern))
+							copy(patternClone, pattern)
+							got = append(got, patternClone)
+						})
+						if !reflect.DeepEqual(got, want) {
+							t.Errorf("callback: want: %v, got: %v", want, got)
+						}
+
+						got = [][]int{}
+						it := target.iterator(c.n, c.k, o.order)
+						for it.Next() {
+							if it.Rank() != len(got) {
+								t.Errorf("iterator rank: want: %d, got: %d", len(got), it.Rank())
+							}
+							patternClone := make([]int, len(it.Pattern()))
+							copy(patternClone, it.Pattern())
+							got = append(got, patternClone)
+						}
+						if !reflect.DeepEqual(got, want) {
+							t.Errorf("iterator: want: %v, got: %v", want, got)
+						}
+
+						for rank, pattern := range want {
+							if got := target.rank(c.n, pattern, o.order); got != rank {
+								t.Errorf("rank of %v: want: %d, got: %d", pattern, rank, got)
+							}
+
+							got := make([]int, c.k)
+							target.unrank(c.n, rank, got, o.order)
+							if !reflect.DeepEqual(got, pattern) {
+								t.Errorf("unrank of %d: want: %v, got: %v", rank, pattern, got)
+							}
+						}
+
+						// seek to the middle and go to the end
+						middle := len(want) / 2
+						it.Seek(middle)
+						for rank := middle; rank < len(want); rank++ {
+							if !it.Next() {
+								t.Fatalf("seek: stopped at %d", rank)
+							}
+							if !reflect.DeepEqual(it.Pattern(), want[rank]) {
+								t.Errorf("seek: want: %v, got: %v", want[rank], it.Pattern())
+							}
+						}
+						if it.Next() {
+							t.Errorf("seek: not stopped at the end")
+						}
+
+						// a negative index is clamped to the first pattern
+						it.Seek(-1)
+						if !it.Next() || !reflect.DeepEqual(it.Pattern(), want[0]) {
+							t.Errorf("seek -1: want: %v, got: %v", want[0], it.Pattern())
+						}
+						if it.Rank() != 0 {
+							t.Errorf("seek -1: rank: want: 0, got: %d", it.Rank())
+						}
+					})
+				}
+			}
+		})
+	}
+}
+
+func lexLess(a, b []int) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
+
+func colexLess(a, b []int) bool {
+	for i := len(a) - 1; i >= 0; i-- {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
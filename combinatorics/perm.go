@@ -1,5 +1,7 @@
 package combinatorics
 
+import "github.com/ikngtty/benchmark-go-combinatorics/internal/container"
+
 // PermutationsRecursive0 is a naive recursive implementation.
 func PermutationsRecursive0(a []int, k int) [][]int {
 	if k == 0 {
@@ -117,10 +119,10 @@ func PermutationsRecursive3(n, k int, f func([]int)) {
 func PermutationsRecursive4(n, k int, f func([]int)) {
 	checklist := make([]bool, n)
 
-	var body func(k int, f func(*IntList))
-	body = func(k int, f func(*IntList)) {
+	var body func(k int, f func(*container.List[int]))
+	body = func(k int, f func(*container.List[int])) {
 		if k == 0 {
-			f(NewIntList())
+			f(container.NewList[int]())
 			return
 		}
 
@@ -130,8 +132,8 @@ func PermutationsRecursive4(n, k int, f func([]int)) {
 			}
 
 			checklist[num] = true
-			body(k-1, func(childPattern *IntList) {
-				pattern := NewIntList()
+			body(k-1, func(childPattern *container.List[int]) {
+				pattern := container.NewList[int]()
 				pattern.Add(num)
 				pattern.Concat(childPattern)
 				f(pattern)
@@ -139,8 +141,8 @@ func PermutationsRecursive4(n, k int, f func([]int)) {
 			checklist[num] = false
 		}
 	}
-	body(k, func(list *IntList) {
-		f(list.ToA())
+	body(k, func(list *container.List[int]) {
+		f(list.ToSlice())
 	})
 }
 
@@ -233,19 +235,24 @@ func PermutationsRecursive7(n, k int, f func([]int)) {
 // PermutationsWithStack0 does not use recursive calls. Instead, it uses
 // a stack that imitates the recursive call stack.
 func PermutationsWithStack0(n, k int, f func([]int)) {
+	permutationsWithStack0(n, k, f, container.NewLinkedStack[*callStackItem0]())
+}
+
+// permutationsWithStack0 is the body of PermutationsWithStack0 with
+// the stack given.
+func permutationsWithStack0(n, k int, f func([]int), callStack container.Stack[*callStackItem0]) {
 	checklist := make([]bool, n)
-	callStack := newCallStack0()
 	pattern := make([]int, k)
 
-	callStack.push(&callStackItem0{pos: 0, chosenNumber: -1})
-	for !callStack.empty() {
-		env := callStack.peek()
+	callStack.Push(&callStackItem0{pos: 0, chosenNumber: -1})
+	for !callStack.Empty() {
+		env := callStack.Peek()
 
 		// at the most right digit, call back the function
 		if env.pos == k {
 			f(pattern)
 
-			callStack.pop()
+			callStack.Pop()
 			continue
 		}
 
@@ -270,7 +277,7 @@ func PermutationsWithStack0(n, k int, f func([]int)) {
 			newEnv := callStackItem0{
 				pos: env.pos + 1, chosenNumber: -1,
 			}
-			callStack.push(&newEnv)
+			callStack.Push(&newEnv)
 			willContinue = true
 			break
 		}
@@ -280,15 +287,20 @@ func PermutationsWithStack0(n, k int, f func([]int)) {
 
 		// the case it cannot increment the digit
 		// -> remove the stack item
-		callStack.pop()
+		callStack.Pop()
 	}
 }
 
 // PermutationsWithStack1 does not store the chosen number in the psuedo
 // "call stack". It refers the current generating permutation instead.
 func PermutationsWithStack1(n, k int, f func([]int)) {
+	permutationsWithStack1(n, k, f, container.NewLinkedStack[int]())
+}
+
+// permutationsWithStack1 is the body of PermutationsWithStack1 with
+// the stack given.
+func permutationsWithStack1(n, k int, f func([]int), posStack container.Stack[int]) {
 	checklist := make([]bool, n)
-	posStack := NewIntStack()
 	pattern := make([]int, k)
 	for i := range pattern {
 		pattern[i] = -1
@@ -342,13 +354,23 @@ func PermutationsWithStack1(n, k int, f func([]int)) {
 
 // PermutationsWithStack2 stores nodes of permutations in a stack.
 func PermutationsWithStack2(n, k int, f func([]int)) {
+	permutationsWithStack2(n, k, f, container.NewLinkedStack[*patternNode2]())
+}
+
+// permutationsWithStack2 is the body of PermutationsWithStack2 with
+// the stack given.
+func permutationsWithStack2(n, k int, f func([]int), patternNodeStack container.Stack[*patternNode2]) {
+	if k > n {
+		// no patterns
+		return
+	}
+
 	checklist := make([]bool, n)
-	patternNodeStack := newPatternNodeStack2()
 	pattern := make([]int, k)
 
-	patternNodeStack.push(&patternNode2{pos: -1, number: 0})
-	for !patternNodeStack.empty() {
-		patternNode := patternNodeStack.pop()
+	patternNodeStack.Push(&patternNode2{pos: -1, number: 0})
+	for !patternNodeStack.Empty() {
+		patternNode := patternNodeStack.Pop()
 
 		// reset the right digits of `checklist` and `pattern`
 		for i := patternNode.pos; i < k; i++ {
@@ -381,20 +403,30 @@ func PermutationsWithStack2(n, k int, f func([]int)) {
 			childNode := patternNode2{
 				pos: patternNode.pos + 1, number: num,
 			}
-			patternNodeStack.push(&childNode)
+			patternNodeStack.Push(&childNode)
 		}
 	}
 }
 
 // PermutationsWithStack3 treats a stack item as a value, not as a reference.
 func PermutationsWithStack3(n, k int, f func([]int)) {
+	permutationsWithStack3(n, k, f, container.NewLinkedStack[patternNode3]())
+}
+
+// permutationsWithStack3 is the body of PermutationsWithStack3 with
+// the stack given.
+func permutationsWithStack3(n, k int, f func([]int), patternNodeStack container.Stack[patternNode3]) {
+	if k > n {
+		// no patterns
+		return
+	}
+
 	checklist := make([]bool, n)
-	patternNodeStack := newPatternNodeStack3()
 	pattern := make([]int, k)
 
-	patternNodeStack.push(patternNode3{pos: -1, number: 0})
-	for !patternNodeStack.empty() {
-		patternNode := patternNodeStack.pop()
+	patternNodeStack.Push(patternNode3{pos: -1, number: 0})
+	for !patternNodeStack.Empty() {
+		patternNode := patternNodeStack.Pop()
 
 		// reset the right digits of `checklist` and `pattern`
 		for i := patternNode.pos; i < k; i++ {
@@ -427,7 +459,7 @@ func PermutationsWithStack3(n, k int, f func([]int)) {
 			childNode := patternNode3{
 				pos: patternNode.pos + 1, number: num,
 			}
-			patternNodeStack.push(childNode)
+			patternNodeStack.Push(childNode)
 		}
 	}
 }
@@ -436,8 +468,13 @@ func PermutationsWithStack3(n, k int, f func([]int)) {
 // permutations, while other functions do from the sentinel node, so to speak,
 // which is for the "zeroth digit".
 func PermutationsWithStack4(n, k int, f func([]int)) {
+	permutationsWithStack4(n, k, f, container.NewLinkedStack[patternNode3]())
+}
+
+// permutationsWithStack4 is the body of PermutationsWithStack4 with
+// the stack given.
+func permutationsWithStack4(n, k int, f func([]int), patternNodeStack container.Stack[patternNode3]) {
 	checklist := make([]bool, n)
-	patternNodeStack := newPatternNodeStack3()
 	pattern := make([]int, k)
 
 	if k == 0 {
@@ -446,11 +483,11 @@ func PermutationsWithStack4(n, k int, f func([]int)) {
 	}
 
 	for num := n - 1; num >= 0; num-- {
-		patternNodeStack.push(patternNode3{pos: 0, number: num})
+		patternNodeStack.Push(patternNode3{pos: 0, number: num})
 	}
 
-	for !patternNodeStack.empty() {
-		patternNode := patternNodeStack.pop()
+	for !patternNodeStack.Empty() {
+		patternNode := patternNodeStack.Pop()
 
 		// reset the right digits of `checklist` and `pattern`
 		for i := patternNode.pos; i < k; i++ {
@@ -481,7 +518,7 @@ func PermutationsWithStack4(n, k int, f func([]int)) {
 			childNode := patternNode3{
 				pos: patternNode.pos + 1, number: num,
 			}
-			patternNodeStack.push(childNode)
+			patternNodeStack.Push(childNode)
 		}
 	}
 }
@@ -489,14 +526,19 @@ func PermutationsWithStack4(n, k int, f func([]int)) {
 // PermutationsWithStack5 uses an array of int rather than an array of bool
 // as available numbers.
 func PermutationsWithStack5(a []int, k int, f func([]int)) {
-	patternNodeStack := newPatternNodeStack5()
+	permutationsWithStack5(a, k, f, container.NewLinkedStack[patternNode5]())
+}
+
+// permutationsWithStack5 is the body of PermutationsWithStack5 with
+// the stack given.
+func permutationsWithStack5(a []int, k int, f func([]int), patternNodeStack container.Stack[patternNode5]) {
 	pattern := make([]int, k)
 
 	// NOTE: By measuring performance, it is found that treating a stack item
 	// as a value is still faster than treating it as a reference.
-	patternNodeStack.push(patternNode5{pos: -1, number: 0, rest: a})
-	for !patternNodeStack.empty() {
-		patternNode := patternNodeStack.pop()
+	patternNodeStack.Push(patternNode5{pos: -1, number: 0, rest: a})
+	for !patternNodeStack.Empty() {
+		patternNode := patternNodeStack.Pop()
 
 		// fill the number
 		if patternNode.pos > -1 {
@@ -526,7 +568,7 @@ func PermutationsWithStack5(a []int, k int, f func([]int)) {
 				number: patternNode.rest[i],
 				rest:   newRest,
 			}
-			patternNodeStack.push(childNode)
+			patternNodeStack.Push(childNode)
 		}
 	}
 }
@@ -534,12 +576,17 @@ func PermutationsWithStack5(a []int, k int, f func([]int)) {
 // PermutationsWithStack6 does not record available numbers. Instead, not to
 // use same numbers in a permutation, it checks each digit every time.
 func PermutationsWithStack6(n, k int, f func([]int)) {
-	patternNodeStack := newPatternNodeStack3()
+	permutationsWithStack6(n, k, f, container.NewLinkedStack[patternNode3]())
+}
+
+// permutationsWithStack6 is the body of PermutationsWithStack6 with
+// the stack given.
+func permutationsWithStack6(n, k int, f func([]int), patternNodeStack container.Stack[patternNode3]) {
 	pattern := make([]int, k)
 
-	patternNodeStack.push(patternNode3{pos: -1, number: 0})
-	for !patternNodeStack.empty() {
-		patternNode := patternNodeStack.pop()
+	patternNodeStack.Push(patternNode3{pos: -1, number: 0})
+	for !patternNodeStack.Empty() {
+		patternNode := patternNodeStack.Pop()
 
 		// fill the number
 		if patternNode.pos > -1 {
@@ -570,24 +617,29 @@ func PermutationsWithStack6(n, k int, f func([]int)) {
 			childNode := patternNode3{
 				pos: patternNode.pos + 1, number: num,
 			}
-			patternNodeStack.push(childNode)
+			patternNodeStack.Push(childNode)
 		}
 	}
 }
 
 // PermutationsWithStack7 stores denotions of operation in a stack.
 func PermutationsWithStack7(n, k int, f func([]int)) {
+	permutationsWithStack7(n, k, f, container.NewLinkedStack[operation7]())
+}
+
+// permutationsWithStack7 is the body of PermutationsWithStack7 with
+// the stack given.
+func permutationsWithStack7(n, k int, f func([]int), operationStack container.Stack[operation7]) {
 	checklist := make([]bool, n)
-	operationStack := newOperationStack7()
 	pattern := make([]int, k)
 
-	operationStack.push(operation7{
+	operationStack.Push(operation7{
 		pos:    -1,
 		number: 0,
 		mode:   operationMode7ExecuteOrDelegate,
 	})
-	for !operationStack.empty() {
-		operation := operationStack.pop()
+	for !operationStack.Empty() {
+		operation := operationStack.Pop()
 
 		switch operation.mode {
 		case operationMode7ReflectValue:
@@ -608,17 +660,17 @@ func PermutationsWithStack7(n, k int, f func([]int)) {
 					}
 
 					// push stack items for the right digit
-					operationStack.push(operation7{
+					operationStack.Push(operation7{
 						pos:    operation.pos + 1,
 						number: num,
 						mode:   operationMode7ResetValue,
 					})
-					operationStack.push(operation7{
+					operationStack.Push(operation7{
 						pos:    operation.pos + 1,
 						number: num,
 						mode:   operationMode7ExecuteOrDelegate,
 					})
-					operationStack.push(operation7{
+					operationStack.Push(operation7{
 						pos:    operation.pos + 1,
 						number: num,
 						mode:   operationMode7ReflectValue,
@@ -635,8 +687,13 @@ func PermutationsWithStack7(n, k int, f func([]int)) {
 
 // PermutationsWithStack8 stores functions in a stack.
 func PermutationsWithStack8(n, k int, f func([]int)) {
+	permutationsWithStack8(n, k, f, container.NewLinkedStack[func()]())
+}
+
+// permutationsWithStack8 is the body of PermutationsWithStack8 with
+// the stack given.
+func permutationsWithStack8(n, k int, f func([]int), operationStack container.Stack[func()]) {
 	checklist := make([]bool, n)
-	operationStack := NewFuncStack()
 	pattern := make([]int, k)
 
 	reflectValue := func(pos, number int) {
@@ -687,21 +744,27 @@ func PermutationsWithStack8(n, k int, f func([]int)) {
 }
 
 // PermutationsWithSlice0 bases on PermutationsWithStack0, but the stack
-// is implemented with a slice, not with a pointer.
+// is implemented with a slice, not with a pointer. It stores the items as
+// values and updates the last one in place.
 func PermutationsWithSlice0(n, k int, f func([]int)) {
+	permutationsWithValueStack0(n, k, f, container.NewSliceStack[callStackItem0]())
+}
+
+// permutationsWithValueStack0 is the body of PermutationsWithSlice0 with
+// the stack given.
+func permutationsWithValueStack0(n, k int, f func([]int), callStack container.Stack[callStackItem0]) {
 	checklist := make([]bool, n)
-	callStack := make([]callStackItem0, 1)
 	pattern := make([]int, k)
 
-	callStack[0] = callStackItem0{pos: 0, chosenNumber: -1}
-	for len(callStack) > 0 {
-		env := &callStack[len(callStack)-1] // peek
+	callStack.Push(callStackItem0{pos: 0, chosenNumber: -1})
+	for !callStack.Empty() {
+		env := callStack.Top()
 
 		// at the most right digit, call back the function
 		if env.pos == k {
 			f(pattern)
 
-			callStack = callStack[:len(callStack)-1] // pop(discard)
+			callStack.Pop()
 			continue
 		}
 
@@ -726,7 +789,7 @@ func PermutationsWithSlice0(n, k int, f func([]int)) {
 			newEnv := callStackItem0{
 				pos: env.pos + 1, chosenNumber: -1,
 			}
-			callStack = append(callStack, newEnv) // push
+			callStack.Push(newEnv)
 			willContinue = true
 			break
 		}
@@ -736,371 +799,109 @@ func PermutationsWithSlice0(n, k int, f func([]int)) {
 
 		// the case it cannot increment the digit
 		// -> remove the stack item
-		callStack = callStack[:len(callStack)-1] // pop(discard)
+		callStack.Pop()
 	}
 }
 
-// PermutationsWithSlice1 bases on PermutationsWithStack1. Omitted below.
+// PermutationsWithSlice1 bases on PermutationsWithStack1 with a slice stack.
 func PermutationsWithSlice1(n, k int, f func([]int)) {
-	checklist := make([]bool, n)
-	posStack := make([]int, 1)
-	pattern := make([]int, k)
-	for i := range pattern {
-		pattern[i] = -1
-	}
-
-	posStack[0] = 0
-	for len(posStack) > 0 {
-		pos := posStack[len(posStack)-1] // peek
-
-		// at the most right digit, call back the function
-		if pos == k {
-			f(pattern)
-
-			posStack = posStack[:len(posStack)-1] // pop(discard)
-			continue
-		}
-
-		// reset the digit of `checklist` before increment the digit
-		chosenNumber := pattern[pos]
-		if chosenNumber > -1 {
-			checklist[chosenNumber] = false
-		}
-
-		// increment the digit
-		willContinue := false
-		for chosenNumber++; chosenNumber < n; chosenNumber++ {
-			// skip if the number of `chosenNumber` is used
-			if checklist[chosenNumber] {
-				continue
-			}
-
-			// fill the number
-			pattern[pos] = chosenNumber
-			checklist[chosenNumber] = true
-
-			// push a stack item for the right digit
-			posStack = append(posStack, pos+1) // push
-			willContinue = true
-			break
-		}
-		if willContinue {
-			continue
-		}
-
-		// the case it cannot increment the digit
-		// -> reset the digit of `pattern` and remove the stack item
-		pattern[pos] = -1
-		posStack = posStack[:len(posStack)-1] // pop(discard)
-	}
+	permutationsWithStack1(n, k, f, container.NewSliceStack[int]())
 }
 
-// PermutationsWithSlice2 bases on PermutationsWithStack2, 3. Omitted below.
+// PermutationsWithSlice2 bases on PermutationsWithStack2, 3. It uses a slice
+// stack with items as values like PermutationsWithStack3.
 func PermutationsWithSlice2(n, k int, f func([]int)) {
-	checklist := make([]bool, n)
-	patternNodeStack := make([]patternNode2, 1)
-	pattern := make([]int, k)
-
-	patternNodeStack[0] = patternNode2{pos: -1, number: 0}
-	for len(patternNodeStack) > 0 {
-		patternNode := patternNodeStack[len(patternNodeStack)-1]      // pop(peek)
-		patternNodeStack = patternNodeStack[:len(patternNodeStack)-1] // pop(discard)
-
-		// reset the right digits of `checklist` and `pattern`
-		for i := patternNode.pos; i < k; i++ {
-			if i > -1 && pattern[i] > -1 {
-				checklist[pattern[i]] = false
-				pattern[i] = -1
-			}
-		}
-
-		// fill the number
-		if patternNode.pos > -1 {
-			pattern[patternNode.pos] = patternNode.number
-			checklist[patternNode.number] = true
-		}
-
-		// at the most right digit, call back the function
-		if patternNode.pos == k-1 {
-			f(pattern)
-			continue
-		}
-
-		// enumerate the numbers of the right digit
-		for num := n - 1; num >= 0; num-- {
-			// skip if the number of `num` is used
-			if checklist[num] {
-				continue
-			}
-
-			// push a stack item for the right digit
-			childNode := patternNode2{
-				pos: patternNode.pos + 1, number: num,
-			}
-			patternNodeStack = append(patternNodeStack, childNode) // push
-		}
-	}
+	permutationsWithStack3(n, k, f, container.NewSliceStack[patternNode3]())
 }
 
-// PermutationsWithSlice4 bases on PermutationsWithStack4. Omitted below.
+// PermutationsWithSlice4 bases on PermutationsWithStack4 with a slice stack.
 func PermutationsWithSlice4(n, k int, f func([]int)) {
-	checklist := make([]bool, n)
-	pattern := make([]int, k)
-
-	if k == 0 {
-		f(pattern)
-		return
-	}
-
-	patternNodeStack := make([]patternNode3, n)
-	for num := 0; num < n; num++ {
-		patternNodeStack[n-1-num] = patternNode3{pos: 0, number: num}
-	}
-
-	for len(patternNodeStack) > 0 {
-		patternNode := patternNodeStack[len(patternNodeStack)-1]      // pop(peek)
-		patternNodeStack = patternNodeStack[:len(patternNodeStack)-1] // pop(discard)
-
-		// reset the right digits of `checklist` and `pattern`
-		for i := patternNode.pos; i < k; i++ {
-			if pattern[i] > -1 {
-				checklist[pattern[i]] = false
-				pattern[i] = -1
-			}
-		}
-
-		// fill the number
-		pattern[patternNode.pos] = patternNode.number
-		checklist[patternNode.number] = true
-
-		// at the most right digit, call back the function
-		if patternNode.pos == k-1 {
-			f(pattern)
-			continue
-		}
-
-		// enumerate the numbers of the right digit
-		for num := n - 1; num >= 0; num-- {
-			// skip if the number of `num` is used
-			if checklist[num] {
-				continue
-			}
-
-			// push a stack item for the right digit
-			childNode := patternNode3{
-				pos: patternNode.pos + 1, number: num,
-			}
-			patternNodeStack = append(patternNodeStack, childNode) // push
-		}
-	}
+	permutationsWithStack4(n, k, f, container.NewSliceStack[patternNode3]())
 }
 
-// PermutationsWithSlice5 bases on PermutationsWithStack5. Omitted below.
+// PermutationsWithSlice5 bases on PermutationsWithStack5 with a slice stack.
 func PermutationsWithSlice5(a []int, k int, f func([]int)) {
-	patternNodeStack := make([]patternNode5, 1)
-	pattern := make([]int, k)
-
-	patternNodeStack[0] = patternNode5{pos: -1, number: 0, rest: a}
-	for len(patternNodeStack) > 0 {
-		patternNode := patternNodeStack[len(patternNodeStack)-1]      // pop(peek)
-		patternNodeStack = patternNodeStack[:len(patternNodeStack)-1] // pop(discard)
-
-		// fill the number
-		if patternNode.pos > -1 {
-			pattern[patternNode.pos] = patternNode.number
-		}
-
-		// at the most right digit, call back the function
-		if patternNode.pos == k-1 {
-			f(pattern)
-			continue
-		}
-
-		// enumerate the numbers of the right digit
-		for i := len(patternNode.rest) - 1; i >= 0; i-- {
-			// make a new array without i-th item
-			newRest := make([]int, len(patternNode.rest)-1)
-			for j := 0; j < i; j++ {
-				newRest[j] = patternNode.rest[j]
-			}
-			for j := i + 1; j < len(patternNode.rest); j++ {
-				newRest[j-1] = patternNode.rest[j]
-			}
-
-			// push a stack item for the right digit
-			childNode := patternNode5{
-				pos:    patternNode.pos + 1,
-				number: patternNode.rest[i],
-				rest:   newRest,
-			}
-			patternNodeStack = append(patternNodeStack, childNode) // push
-		}
-	}
+	permutationsWithStack5(a, k, f, container.NewSliceStack[patternNode5]())
 }
 
-// PermutationsWithSlice6 bases on PermutationsWithStack6. Omitted below.
+// PermutationsWithSlice6 bases on PermutationsWithStack6 with a slice stack.
 func PermutationsWithSlice6(n, k int, f func([]int)) {
-	patternNodeStack := make([]patternNode3, 1)
-	pattern := make([]int, k)
-
-	patternNodeStack[0] = patternNode3{pos: -1, number: 0}
-	for len(patternNodeStack) > 0 {
-		patternNode := patternNodeStack[len(patternNodeStack)-1]      // pop(peek)
-		patternNodeStack = patternNodeStack[:len(patternNodeStack)-1] // pop(discard)
-
-		// fill the number
-		if patternNode.pos > -1 {
-			pattern[patternNode.pos] = patternNode.number
-		}
-
-		// at the most right digit, call back the function
-		if patternNode.pos == k-1 {
-			f(pattern)
-			continue
-		}
-
-		// enumerate the numbers of the right digit
-		for num := n - 1; num >= 0; num-- {
-			// skip if the number of `num` is used in the left digits
-			willContinue := false
-			for i := 0; i <= patternNode.pos; i++ {
-				if pattern[i] == num {
-					willContinue = true
-					break
-				}
-			}
-			if willContinue {
-				continue
-			}
-
-			// push a stack item for the right digit
-			childNode := patternNode3{
-				pos: patternNode.pos + 1, number: num,
-			}
-			patternNodeStack = append(patternNodeStack, childNode) // push
-		}
-	}
+	permutationsWithStack6(n, k, f, container.NewSliceStack[patternNode3]())
 }
 
-// PermutationsWithSlice7 bases on PermutationsWithStack7. Omitted below.
+// PermutationsWithSlice7 bases on PermutationsWithStack7 with a slice stack.
 func PermutationsWithSlice7(n, k int, f func([]int)) {
-	checklist := make([]bool, n)
-	operationStack := make([]operation7, 1)
-	pattern := make([]int, k)
+	permutationsWithStack7(n, k, f, container.NewSliceStack[operation7]())
+}
 
-	operationStack[0] = operation7{
-		pos:    -1,
-		number: 0,
-		mode:   operationMode7ExecuteOrDelegate,
-	}
-	for len(operationStack) > 0 {
-		operation := operationStack[len(operationStack)-1]      // pop(peek)
-		operationStack = operationStack[:len(operationStack)-1] // pop(discard)
+// PermutationsWithSlice8 bases on PermutationsWithStack8 with a slice stack.
+func PermutationsWithSlice8(n, k int, f func([]int)) {
+	permutationsWithStack8(n, k, f, container.NewSliceStack[func()]())
+}
 
-		switch operation.mode {
-		case operationMode7ReflectValue:
-			// fill the number
-			pattern[operation.pos] = operation.number
-			checklist[operation.number] = true
+// PermutationsWithStackPooled0 bases on PermutationsWithStack0 with a pooled
+// stack. It stores the items as values like PermutationsWithSlice0.
+func PermutationsWithStackPooled0(n, k int, f func([]int)) {
+	permutationsWithValueStack0(n, k, f, container.NewPooledStack[callStackItem0]())
+}
 
-		case operationMode7ExecuteOrDelegate:
-			if operation.pos == k-1 {
-				// at the most right digit, call back the function
-				f(pattern)
-			} else {
-				// enumerate the numbers of the right digit
-				for num := n - 1; num >= 0; num-- {
-					// skip if the number of `num` is used
-					if checklist[num] {
-						continue
-					}
+// PermutationsWithStackPooled1 bases on PermutationsWithStack1 with a pooled
+// stack.
+func PermutationsWithStackPooled1(n, k int, f func([]int)) {
+	permutationsWithStack1(n, k, f, container.NewPooledStack[int]())
+}
 
-					// push stack items for the right digit
-					operationStack = append(operationStack,
-						operation7{
-							pos:    operation.pos + 1,
-							number: num,
-							mode:   operationMode7ResetValue,
-						},
-						operation7{
-							pos:    operation.pos + 1,
-							number: num,
-							mode:   operationMode7ExecuteOrDelegate,
-						},
-						operation7{
-							pos:    operation.pos + 1,
-							number: num,
-							mode:   operationMode7ReflectValue,
-						}) // push
-				}
-			}
+// PermutationsWithStackPooled2 bases on PermutationsWithStack2, 3 with
+// a pooled stack. It stores the items as values like PermutationsWithStack3.
+func PermutationsWithStackPooled2(n, k int, f func([]int)) {
+	permutationsWithStack3(n, k, f, container.NewPooledStack[patternNode3]())
+}
 
-		case operationMode7ResetValue:
-			// reset the digit of `checklist`
-			checklist[operation.number] = false
-		}
-	}
+// PermutationsWithStackPooled3 bases on PermutationsWithStack3 with a pooled
+// stack.
+func PermutationsWithStackPooled3(n, k int, f func([]int)) {
+	permutationsWithStack3(n, k, f, container.NewPooledStack[patternNode3]())
 }
 
-// PermutationsWithSlice8 bases on PermutationsWithStack8. Omitted below.
-func PermutationsWithSlice8(n, k int, f func([]int)) {
-	checklist := make([]bool, n)
-	operationStack := make([]func(), 1)
-	pattern := make([]int, k)
+// PermutationsWithStackPooled4 bases on PermutationsWithStack4 with a pooled
+// stack.
+func PermutationsWithStackPooled4(n, k int, f func([]int)) {
+	permutationsWithStack4(n, k, f, container.NewPooledStack[patternNode3]())
+}
 
-	reflectValue := func(pos, number int) {
-		// fill the number
-		pattern[pos] = number
-		checklist[number] = true
-	}
-	resetValue := func(number int) {
-		// reset the digit of `checklist`
-		checklist[number] = false
-	}
-	var executeOrDelegate func(pos int)
-	executeOrDelegate = func(pos int) {
-		// at the most right digit, call back the function
-		if pos == k-1 {
-			f(pattern)
-			return
-		}
+// PermutationsWithStackPooled5 bases on PermutationsWithStack5 with a pooled
+// stack.
+func PermutationsWithStackPooled5(a []int, k int, f func([]int)) {
+	permutationsWithStack5(a, k, f, container.NewPooledStack[patternNode5]())
+}
 
-		// enumerate the numbers of the right digit
-		for num := n - 1; num >= 0; num-- {
-			// skip if the number of `num` is used
-			if checklist[num] {
-				continue
-			}
+// PermutationsWithStackPooled6 bases on PermutationsWithStack6 with a pooled
+// stack.
+func PermutationsWithStackPooled6(n, k int, f func([]int)) {
+	permutationsWithStack6(n, k, f, container.NewPooledStack[patternNode3]())
+}
 
-			// push stack items for the right digit
-			numFrozen := num
-			operationStack = append(operationStack,
-				func() {
-					resetValue(numFrozen)
-				},
-				func() {
-					executeOrDelegate(pos + 1)
-				},
-				func() {
-					reflectValue(pos+1, numFrozen)
-				}) // push
-		}
-	}
+// PermutationsWithStackPooled7 bases on PermutationsWithStack7 with a pooled
+// stack.
+func PermutationsWithStackPooled7(n, k int, f func([]int)) {
+	permutationsWithStack7(n, k, f, container.NewPooledStack[operation7]())
+}
 
-	operationStack[0] = func() {
-		executeOrDelegate(-1)
-	}
-	for len(operationStack) > 0 {
-		operation := operationStack[len(operationStack)-1]      // pop(peek)
-		operationStack = operationStack[:len(operationStack)-1] // pop(discard)
-		operation()
-	}
+// PermutationsWithStackPooled8 bases on PermutationsWithStack8 with a pooled
+// stack.
+func PermutationsWithStackPooled8(n, k int, f func([]int)) {
+	permutationsWithStack8(n, k, f, container.NewPooledStack[func()]())
 }
 
 // PermutationsWithCarrying0 decides the next digit of permutation
 // to increment not by a stack or recursive calls but by the previous
 // permutation directly.
 func PermutationsWithCarrying0(n, k int, f func([]int)) {
+	if k > n {
+		// no patterns
+		return
+	}
+
 	pattern := make([]int, k)
 	for i := range pattern {
 		pattern[i] = i
@@ -1170,7 +971,13 @@ func PermutationsWithCarrying0(n, k int, f func([]int)) {
 }
 
 // PermutationsWithCarrying1 records available numbers to an array of bool.
+// See NextPartialPermutation for the increment.
 func PermutationsWithCarrying1(n, k int, f func([]int)) {
+	if k > n {
+		// no patterns
+		return
+	}
+
 	checklist := make([]bool, n)
 	pattern := make([]int, k)
 	for i := range pattern {
@@ -1181,51 +988,8 @@ func PermutationsWithCarrying1(n, k int, f func([]int)) {
 	for {
 		f(pattern)
 
-		// increment
-		pos := k - 1 // current digit
-		for {
-			if pos == -1 {
-				return
-			}
-
-			oldNum := pattern[pos]
-			checklist[oldNum] = false
-
-			willBreak := false
-			for newNum := oldNum + 1; newNum < n; newNum++ {
-				// skip if the number of `newNum` is used
-				if checklist[newNum] {
-					continue
-				}
-
-				// increment the value of the current digit
-				pattern[pos] = newNum
-				checklist[newNum] = true
-				willBreak = true
-				break
-			}
-			if willBreak {
-				break
-			}
-
-			// the case it cannot increment the current digit
-			// -> carry
-			pos--
-		}
-
-		// replace the numbers of carried digits
-		for pos++; pos < k; pos++ {
-			for num := 0; num < k; num++ {
-				// skip if the number of `num` is used
-				if checklist[num] {
-					continue
-				}
-
-				// replace
-				pattern[pos] = num
-				checklist[num] = true
-				break
-			}
+		if !NextPartialPermutation(n, pattern, checklist) {
+			return
 		}
 	}
 }
@@ -1234,6 +998,11 @@ func PermutationsWithCarrying1(n, k int, f func([]int)) {
 // which goes from right digit to left digit, and the one for setting rest
 // values, which goes from left digit to right digit.
 func PermutationsWithCarrying2(n, k int, f func([]int)) {
+	if k > n {
+		// no patterns
+		return
+	}
+
 	checklist := make([]bool, n)
 	pattern := make([]int, k)
 	for i := range pattern {
@@ -1276,150 +1045,208 @@ func PermutationsWithCarrying2(n, k int, f func([]int)) {
 	}
 }
 
-// PermutationCount computes the number of permutations.
-func PermutationCount(n, k int) int {
-	ans := 1
-	for i := 0; i < k; i++ {
-		ans *= n - i
+// NextPermutation rearranges `pattern`, which is a permutation of all
+// the numbers, into the next permutation in lexicographic order like
+// std::next_permutation in C++. If `pattern` is the last one, it rearranges
+// `pattern` into the first one and returns false.
+func NextPermutation(pattern []int) bool {
+	// find the most right digit smaller than its right neighbor
+	pos := len(pattern) - 2
+	for pos >= 0 && pattern[pos] >= pattern[pos+1] {
+		pos--
+	}
+	if pos < 0 {
+		reverseInts(pattern)
+		return false
 	}
-	return ans
-}
 
-type callStackItem0 struct {
-	pos          int
-	chosenNumber int
-}
+	// swap it for the smallest greater number in the right digits
+	swapPos := len(pattern) - 1
+	for pattern[swapPos] <= pattern[pos] {
+		swapPos--
+	}
+	pattern[pos], pattern[swapPos] = pattern[swapPos], pattern[pos]
 
-type callStack0 struct {
-	last *callStack0Node
+	reverseInts(pattern[pos+1:])
+	return true
 }
 
-type callStack0Node struct {
-	parent *callStack0Node
-	value  *callStackItem0
-}
+// PrevPermutation rearranges `pattern`, which is a permutation of all
+// the numbers, into the previous permutation in lexicographic order like
+// std::prev_permutation in C++. If `pattern` is the first one, it rearranges
+// `pattern` into the last one and returns false.
+func PrevPermutation(pattern []int) bool {
+	// find the most right digit greater than its right neighbor
+	pos := len(pattern) - 2
+	for pos >= 0 && pattern[pos] <= pattern[pos+1] {
+		pos--
+	}
+	if pos < 0 {
+		reverseInts(pattern)
+		return false
+	}
 
-func newCallStack0() *callStack0 {
-	return &callStack0{nil}
-}
+	// swap it for the greatest smaller number in the right digits
+	swapPos := len(pattern) - 1
+	for pattern[swapPos] >= pattern[pos] {
+		swapPos--
+	}
+	pattern[pos], pattern[swapPos] = pattern[swapPos], pattern[pos]
 
-func (s *callStack0) push(elem *callStackItem0) {
-	node := callStack0Node{s.last, elem}
-	s.last = &node
+	reverseInts(pattern[pos+1:])
+	return true
 }
 
-func (s *callStack0) pop() *callStackItem0 {
-	value := s.last.value
-	s.last = s.last.parent
-	return value
-}
+// NextPartialPermutation rearranges `pattern`, which is a permutation of
+// `len(pattern)` numbers from `n` numbers, into the next permutation in
+// lexicographic order. `checklist` records the numbers used in `pattern` and
+// is updated together. If `pattern` is the last one, it rearranges `pattern`
+// into the first one and returns false.
+func NextPartialPermutation(n int, pattern []int, checklist []bool) bool {
+	k := len(pattern)
 
-func (s *callStack0) peek() *callStackItem0 {
-	return s.last.value
-}
+	// increment
+	pos := k - 1 // current digit
+	for {
+		if pos == -1 {
+			for i := range pattern {
+				pattern[i] = i
+				checklist[i] = true
+			}
+			return false
+		}
 
-func (s *callStack0) empty() bool {
-	return s.last == nil
-}
+		oldNum := pattern[pos]
+		checklist[oldNum] = false
 
-type patternNode2 struct {
-	pos    int
-	number int
-}
+		willBreak := false
+		for newNum := oldNum + 1; newNum < n; newNum++ {
+			// skip if the number of `newNum` is used
+			if checklist[newNum] {
+				continue
+			}
 
-type patternNodeStack2 struct {
-	last *patternNodeStack2Node
-}
+			// increment the value of the current digit
+			pattern[pos] = newNum
+			checklist[newNum] = true
+			willBreak = true
+			break
+		}
+		if willBreak {
+			break
+		}
 
-type patternNodeStack2Node struct {
-	parent *patternNodeStack2Node
-	value  *patternNode2
-}
+		// the case it cannot increment the current digit
+		// -> carry
+		pos--
+	}
 
-func newPatternNodeStack2() *patternNodeStack2 {
-	return &patternNodeStack2{nil}
-}
+	// replace the numbers of carried digits
+	for pos++; pos < k; pos++ {
+		for num := 0; num < k; num++ {
+			// skip if the number of `num` is used
+			if checklist[num] {
+				continue
+			}
 
-func (s *patternNodeStack2) push(elem *patternNode2) {
-	node := patternNodeStack2Node{s.last, elem}
-	s.last = &node
+			// replace
+			pattern[pos] = num
+			checklist[num] = true
+			break
+		}
+	}
+	return true
 }
 
-func (s *patternNodeStack2) pop() *patternNode2 {
-	value := s.last.value
-	s.last = s.last.parent
-	return value
-}
+// PrevPartialPermutation rearranges `pattern`, which is a permutation of
+// `len(pattern)` numbers from `n` numbers, into the previous permutation in
+// lexicographic order. `checklist` records the numbers used in `pattern` and
+// is updated together. If `pattern` is the first one, it rearranges `pattern`
+// into the last one and returns false.
+func PrevPartialPermutation(n int, pattern []int, checklist []bool) bool {
+	k := len(pattern)
 
-func (s *patternNodeStack2) empty() bool {
-	return s.last == nil
-}
+	// decrement
+	pos := k - 1 // current digit
+	for {
+		if pos == -1 {
+			for i := range pattern {
+				pattern[i] = n - 1 - i
+				checklist[n-1-i] = true
+			}
+			return false
+		}
 
-type patternNode3 struct {
-	pos    int
-	number int
-}
+		oldNum := pattern[pos]
+		checklist[oldNum] = false
 
-type patternNodeStack3 struct {
-	last *patternNodeStack3Node
-}
+		willBreak := false
+		for newNum := oldNum - 1; newNum >= 0; newNum-- {
+			// skip if the number of `newNum` is used
+			if checklist[newNum] {
+				continue
+			}
 
-type patternNodeStack3Node struct {
-	parent *patternNodeStack3Node
-	value  patternNode3
-}
+			// decrement the value of the current digit
+			pattern[pos] = newNum
+			checklist[newNum] = true
+			willBreak = true
+			break
+		}
+		if willBreak {
+			break
+		}
 
-func newPatternNodeStack3() *patternNodeStack3 {
-	return &patternNodeStack3{nil}
-}
+		// the case it cannot decrement the current digit
+		// -> borrow
+		pos--
+	}
 
-func (s *patternNodeStack3) push(elem patternNode3) {
-	node := patternNodeStack3Node{s.last, elem}
-	s.last = &node
+	// replace the numbers of borrowed digits
+	for pos++; pos < k; pos++ {
+		for num := n - 1; num >= 0; num-- {
+			// skip if the number of `num` is used
+			if checklist[num] {
+				continue
+			}
+
+			// replace
+			pattern[pos] = num
+			checklist[num] = true
+			break
+		}
+	}
+	return true
 }
 
-func (s *patternNodeStack3) pop() patternNode3 {
-	value := s.last.value
-	s.last = s.last.parent
-	return value
+// PermutationCount computes the number of permutations.
+func PermutationCount(n, k int) int {
+	ans := 1
+	for i := 0; i < k; i++ {
+		ans *= n - i
+	}
+	return ans
 }
 
-func (s *patternNodeStack3) empty() bool {
-	return s.last == nil
+type callStackItem0 struct {
+	pos          int
+	chosenNumber int
 }
 
-type patternNode5 struct {
+type patternNode2 struct {
 	pos    int
 	number int
-	rest   []int
-}
-
-type patternNodeStack5 struct {
-	last *patternNodeStack5Node
-}
-
-type patternNodeStack5Node struct {
-	parent *patternNodeStack5Node
-	value  patternNode5
-}
-
-func newPatternNodeStack5() *patternNodeStack5 {
-	return &patternNodeStack5{nil}
 }
 
-func (s *patternNodeStack5) push(elem patternNode5) {
-	node := patternNodeStack5Node{s.last, elem}
-	s.last = &node
-}
-
-func (s *patternNodeStack5) pop() patternNode5 {
-	value := s.last.value
-	s.last = s.last.parent
-	return value
+type patternNode3 struct {
+	pos    int
+	number int
 }
 
-func (s *patternNodeStack5) empty() bool {
-	return s.last == nil
+type patternNode5 struct {
+	pos    int
+	number int
+	rest   []int
 }
 
 const (
@@ -1433,31 +1260,3 @@ type operation7 struct {
 	number int
 	mode   int
 }
-
-type operationStack7 struct {
-	last *operationStack7Node
-}
-
-type operationStack7Node struct {
-	parent *operationStack7Node
-	value  operation7
-}
-
-func newOperationStack7() *operationStack7 {
-	return &operationStack7{nil}
-}
-
-func (s *operationStack7) push(elem operation7) {
-	node := operationStack7Node{s.last, elem}
-	s.last = &node
-}
-
-func (s *operationStack7) pop() operation7 {
-	value := s.last.value
-	s.last = s.last.parent
-	return value
-}
-
-func (s *operationStack7) empty() bool {
-	return s.last == nil
-}
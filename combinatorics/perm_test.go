@@ -7,321 +7,6 @@ import (
 )
 
 func TestPermutations(t *testing.T) {
-	targets := []struct {
-		name string
-		f    func(n, k int) [][]int
-	}{
-		{"Recursive0",
-			func(n, k int) [][]int {
-				a := make([]int, n)
-				for i := 0; i < n; i++ {
-					a[i] = i
-				}
-				return PermutationsRecursive0(a, k)
-			}},
-		{"Recursive1",
-			func(n, k int) [][]int {
-				a := make([]int, n)
-				for i := 0; i < n; i++ {
-					a[i] = i
-				}
-				return PermutationsRecursive1(a, k)
-			}},
-		{"Recursive2",
-			func(n, k int) [][]int {
-				a := make([]int, n)
-				for i := 0; i < n; i++ {
-					a[i] = i
-				}
-
-				got := [][]int{}
-				PermutationsRecursive2(a, k,
-					func(pattern []int) {
-						got = append(got, pattern)
-					})
-				return got
-			}},
-		{"Recursive3",
-			func(n, k int) [][]int {
-				got := [][]int{}
-				PermutationsRecursive3(n, k,
-					func(pattern []int) {
-						got = append(got, pattern)
-					})
-				return got
-			}},
-		{"Recursive4",
-			func(n, k int) [][]int {
-				got := [][]int{}
-				PermutationsRecursive4(n, k,
-					func(pattern []int) {
-						got = append(got, pattern)
-					})
-				return got
-			}},
-		{"Recursive5",
-			func(n, k int) [][]int {
-				got := [][]int{}
-				PermutationsRecursive5(n, k,
-					func(pattern []int) {
-						got = append(got, pattern)
-					})
-				return got
-			}},
-		{"Recursive6",
-			func(n, k int) [][]int {
-				got := [][]int{}
-				PermutationsRecursive6(n, k,
-					func(pattern []int) {
-						patternClone := make([]int, len(pattern))
-						copy(patternClone, pattern)
-						got = append(got, patternClone)
-					})
-				return got
-			}},
-		{"Recursive7",
-			func(n, k int) [][]int {
-				got := [][]int{}
-				PermutationsRecursive7(n, k,
-					func(pattern []int) {
-						patternClone := make([]int, len(pattern))
-						copy(patternClone, pattern)
-						got = append(got, patternClone)
-					})
-				return got
-			}},
-		{"WithStack0",
-			func(n, k int) [][]int {
-				got := [][]int{}
-				PermutationsWithStack0(n, k,
-					func(pattern []int) {
-						patternClone := make([]int, len(pattern))
-						copy(patternClone, pattern)
-						got = append(got, patternClone)
-					})
-				return got
-			}},
-		{"WithStack1",
-			func(n, k int) [][]int {
-				got := [][]int{}
-				PermutationsWithStack1(n, k,
-					func(pattern []int) {
-						patternClone := make([]int, len(pattern))
-						copy(patternClone, pattern)
-						got = append(got, patternClone)
-					})
-				return got
-			}},
-		{"WithStack2",
-			func(n, k int) [][]int {
-				got := [][]int{}
-				PermutationsWithStack2(n, k,
-					func(pattern []int) {
-						patternClone := make([]int, len(pattern))
-						copy(patternClone, pattern)
-						got = append(got, patternClone)
-					})
-				return got
-			}},
-		{"WithStack3",
-			func(n, k int) [][]int {
-				got := [][]int{}
-				PermutationsWithStack3(n, k,
-					func(pattern []int) {
-						patternClone := make([]int, len(pattern))
-						copy(patternClone, pattern)
-						got = append(got, patternClone)
-					})
-				return got
-			}},
-		{"WithStack4",
-			func(n, k int) [][]int {
-				got := [][]int{}
-				PermutationsWithStack4(n, k,
-					func(pattern []int) {
-						patternClone := make([]int, len(pattern))
-						copy(patternClone, pattern)
-						got = append(got, patternClone)
-					})
-				return got
-			}},
-		{"WithStack5",
-			func(n, k int) [][]int {
-				a := make([]int, n)
-				for i := 0; i < n; i++ {
-					a[i] = i
-				}
-
-				got := [][]int{}
-				PermutationsWithStack5(a, k,
-					func(pattern []int) {
-						patternClone := make([]int, len(pattern))
-						copy(patternClone, pattern)
-						got = append(got, patternClone)
-					})
-				return got
-			}},
-		{"WithStack6",
-			func(n, k int) [][]int {
-				got := [][]int{}
-				PermutationsWithStack6(n, k,
-					func(pattern []int) {
-						patternClone := make([]int, len(pattern))
-						copy(patternClone, pattern)
-						got = append(got, patternClone)
-					})
-				return got
-			}},
-		{"WithStack7",
-			func(n, k int) [][]int {
-				got := [][]int{}
-				PermutationsWithStack7(n, k,
-					func(pattern []int) {
-						patternClone := make([]int, len(pattern))
-						copy(patternClone, pattern)
-						got = append(got, patternClone)
-					})
-				return got
-			}},
-		{"WithStack8",
-			func(n, k int) [][]int {
-				got := [][]int{}
-				PermutationsWithStack8(n, k,
-					func(pattern []int) {
-						patternClone := make([]int, len(pattern))
-						copy(patternClone, pattern)
-						got = append(got, patternClone)
-					})
-				return got
-			}},
-		{"WithSlice0",
-			func(n, k int) [][]int {
-				got := [][]int{}
-				PermutationsWithSlice0(n, k,
-					func(pattern []int) {
-						patternClone := make([]int, len(pattern))
-						copy(patternClone, pattern)
-						got = append(got, patternClone)
-					})
-				return got
-			}},
-		{"WithSlice1",
-			func(n, k int) [][]int {
-				got := [][]int{}
-				PermutationsWithSlice1(n, k,
-					func(pattern []int) {
-						patternClone := make([]int, len(pattern))
-						copy(patternClone, pattern)
-						got = append(got, patternClone)
-					})
-				return got
-			}},
-		{"WithSlice2",
-			func(n, k int) [][]int {
-				got := [][]int{}
-				PermutationsWithSlice2(n, k,
-					func(pattern []int) {
-						patternClone := make([]int, len(pattern))
-						copy(patternClone, pattern)
-						got = append(got, patternClone)
-					})
-				return got
-			}},
-		{"WithSlice4",
-			func(n, k int) [][]int {
-				got := [][]int{}
-				PermutationsWithSlice4(n, k,
-					func(pattern []int) {
-						patternClone := make([]int, len(pattern))
-						copy(patternClone, pattern)
-						got = append(got, patternClone)
-					})
-				return got
-			}},
-		{"WithSlice5",
-			func(n, k int) [][]int {
-				a := make([]int, n)
-				for i := 0; i < n; i++ {
-					a[i] = i
-				}
-
-				got := [][]int{}
-				PermutationsWithSlice5(a, k,
-					func(pattern []int) {
-						patternClone := make([]int, len(pattern))
-						copy(patternClone, pattern)
-						got = append(got, patternClone)
-					})
-				return got
-			}},
-		{"WithSlice6",
-			func(n, k int) [][]int {
-				got := [][]int{}
-				PermutationsWithSlice6(n, k,
-					func(pattern []int) {
-						patternClone := make([]int, len(pattern))
-						copy(patternClone, pattern)
-						got = append(got, patternClone)
-					})
-				return got
-			}},
-		{"WithSlice7",
-			func(n, k int) [][]int {
-				got := [][]int{}
-				PermutationsWithSlice7(n, k,
-					func(pattern []int) {
-						patternClone := make([]int, len(pattern))
-						copy(patternClone, pattern)
-						got = append(got, patternClone)
-					})
-				return got
-			}},
-		{"WithSlice8",
-			func(n, k int) [][]int {
-				got := [][]int{}
-				PermutationsWithSlice8(n, k,
-					func(pattern []int) {
-						patternClone := make([]int, len(pattern))
-						copy(patternClone, pattern)
-						got = append(got, patternClone)
-					})
-				return got
-			}},
-		{"WithCarrying0",
-			func(n, k int) [][]int {
-				got := [][]int{}
-				PermutationsWithCarrying0(n, k,
-					func(pattern []int) {
-						patternClone := make([]int, len(pattern))
-						copy(patternClone, pattern)
-						got = append(got, patternClone)
-					})
-				return got
-			}},
-		{"WithCarrying1",
-			func(n, k int) [][]int {
-				got := [][]int{}
-				PermutationsWithCarrying1(n, k,
-					func(pattern []int) {
-						patternClone := make([]int, len(pattern))
-						copy(patternClone, pattern)
-						got = append(got, patternClone)
-					})
-				return got
-			}},
-		{"WithCarrying2",
-			func(n, k int) [][]int {
-				got := [][]int{}
-				PermutationsWithCarrying2(n, k,
-					func(pattern []int) {
-						patternClone := make([]int, len(pattern))
-						copy(patternClone, pattern)
-						got = append(got, patternClone)
-					})
-				return got
-			}},
-	}
-
 	cases := []struct {
 		n, k int
 		want [][]int
@@ -393,11 +78,11 @@ func TestPermutations(t *testing.T) {
 		}},
 	}
 
-	for _, target := range targets {
-		t.Run(target.name, func(t *testing.T) {
+	for _, impl := range Implementations(FamilyPermutations) {
+		t.Run(impl.Name, func(t *testing.T) {
 			for _, c := range cases {
 				t.Run(fmt.Sprintf("n=%d k=%d", c.n, c.k), func(t *testing.T) {
-					got := target.f(c.n, c.k)
+					got := collectPatterns(t, impl, c.n, c.k)
 					if !reflect.DeepEqual(got, c.want) {
 						t.Errorf("want: %v, got: %v", c.want, got)
 					}
@@ -408,163 +93,10 @@ func TestPermutations(t *testing.T) {
 }
 
 func BenchmarkPermutations(b *testing.B) {
-	const n = 10
-	const k = 10
-
-	doSomethingForPattern := func(pattern []int) {
-		total := 0
-		for i := 1; i < len(pattern); i++ {
-			total += pattern[i] - pattern[i-1]
-		}
-	}
-
-	targets := []struct {
-		name string
-		f    func()
-	}{
-		{"Recursive0",
-			func() {
-				a := make([]int, n)
-				for i := 0; i < n; i++ {
-					a[i] = i
-				}
-				for _, pattern := range PermutationsRecursive0(a, k) {
-					doSomethingForPattern(pattern)
-				}
-			}},
-		{"Recursive1",
-			func() {
-				a := make([]int, n)
-				for i := 0; i < n; i++ {
-					a[i] = i
-				}
-				for _, pattern := range PermutationsRecursive1(a, k) {
-					doSomethingForPattern(pattern)
-				}
-			}},
-		{"Recursive2",
-			func() {
-				a := make([]int, n)
-				for i := 0; i < n; i++ {
-					a[i] = i
-				}
-				PermutationsRecursive2(a, k, doSomethingForPattern)
-			}},
-		{"Recursive3",
-			func() {
-				PermutationsRecursive3(n, k, doSomethingForPattern)
-			}},
-		{"Recursive4",
-			func() {
-				PermutationsRecursive4(n, k, doSomethingForPattern)
-			}},
-		{"Recursive5",
-			func() {
-				PermutationsRecursive5(n, k, doSomethingForPattern)
-			}},
-		{"Recursive6",
-			func() {
-				PermutationsRecursive6(n, k, doSomethingForPattern)
-			}},
-		{"Recursive7",
-			func() {
-				PermutationsRecursive7(n, k, doSomethingForPattern)
-			}},
-		{"WithStack0",
-			func() {
-				PermutationsWithStack0(n, k, doSomethingForPattern)
-			}},
-		{"WithStack1",
-			func() {
-				PermutationsWithStack1(n, k, doSomethingForPattern)
-			}},
-		{"WithStack2",
-			func() {
-				PermutationsWithStack2(n, k, doSomethingForPattern)
-			}},
-		{"WithStack3",
-			func() {
-				PermutationsWithStack3(n, k, doSomethingForPattern)
-			}},
-		{"WithStack4",
-			func() {
-				PermutationsWithStack4(n, k, doSomethingForPattern)
-			}},
-		{"WithStack5",
-			func() {
-				a := make([]int, n)
-				for i := 0; i < n; i++ {
-					a[i] = i
-				}
-				PermutationsWithStack5(a, k, doSomethingForPattern)
-			}},
-		{"WithStack6",
-			func() {
-				PermutationsWithStack6(n, k, doSomethingForPattern)
-			}},
-		{"WithStack7",
-			func() {
-				PermutationsWithStack7(n, k, doSomethingForPattern)
-			}},
-		{"WithStack8",
-			func() {
-				PermutationsWithStack8(n, k, doSomethingForPattern)
-			}},
-		{"WithSlice0",
-			func() {
-				PermutationsWithSlice0(n, k, doSomethingForPattern)
-			}},
-		{"WithSlice1",
-			func() {
-				PermutationsWithSlice1(n, k, doSomethingForPattern)
-			}},
-		{"WithSlice2",
-			func() {
-				PermutationsWithSlice2(n, k, doSomethingForPattern)
-			}},
-		{"WithSlice4",
-			func() {
-				PermutationsWithSlice4(n, k, doSomethingForPattern)
-			}},
-		{"WithSlice5",
-			func() {
-				a := make([]int, n)
-				for i := 0; i < n; i++ {
-					a[i] = i
-				}
-				PermutationsWithSlice5(a, k, doSomethingForPattern)
-			}},
-		{"WithSlice6",
-			func() {
-				PermutationsWithSlice6(n, k, doSomethingForPattern)
-			}},
-		{"WithSlice7",
-			func() {
-				PermutationsWithSlice7(n, k, doSomethingForPattern)
-			}},
-		{"WithSlice8",
-			func() {
-				PermutationsWithSlice8(n, k, doSomethingForPattern)
-			}},
-		{"WithCarrying0",
-			func() {
-				PermutationsWithCarrying0(n, k, doSomethingForPattern)
-			}},
-		{"WithCarrying1",
-			func() {
-				PermutationsWithCarrying1(n, k, doSomethingForPattern)
-			}},
-		{"WithCarrying2",
-			func() {
-				PermutationsWithCarrying2(n, k, doSomethingForPattern)
-			}},
-	}
-
-	for _, target := range targets {
-		b.Run(target.name, func(b *testing.B) {
-			for try := 0; try < b.N; try++ {
-				target.f()
-			}
-		})
-	}
+	benchmarkFamily(b, FamilyPermutations, []benchSize{
+		{n: 10, k: 10},
+		{n: 12, k: 6},
+		{n: 10, k: 3},
+		{n: 30, k: 3},
+	})
 }
@@ -0,0 +1,242 @@
+package combinatorics
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrNotPermutation means that numbers are not a permutation of numbers from
+// 0 to n-1.
+var ErrNotPermutation = errors.New("combinatorics: not a permutation")
+
+// Permutation is a bijection on numbers from 0 to n-1, which maps `i` to
+// `p[i]`. A pattern of PermutationsRecursive6 and the others with `k = n`
+// can be converted to it as it is.
+type Permutation []int
+
+// NewPermutation copies `a` into a Permutation. It returns
+// ErrNotPermutation if `a` is not a permutation of numbers from 0 to
+// `len(a)-1`.
+func NewPermutation(a []int) (Permutation, error) {
+	if !FamilyPermutations.validPattern(len(a), a) {
+		return nil, ErrNotPermutation
+	}
+	return append(Permutation{}, a...), nil
+}
+
+// IdentityPermutation returns the identity permutation of `n` numbers.
+func IdentityPermutation(n int) Permutation {
+	return Permutation(numbers(n))
+}
+
+// Len returns `n`.
+func (p Permutation) Len() int {
+	return len(p)
+}
+
+// Compose returns the permutation which maps `i` to `p[q[i]]`, that is, it
+// applies `q` first and then `p`. It panics if the lengths differ.
+func (p Permutation) Compose(q Permutation) Permutation {
+	if len(p) != len(q) {
+		panic("combinatorics: composing permutations of different lengths")
+	}
+	ans := make(Permutation, len(p))
+	for i, qi := range q {
+		ans[i] = p[qi]
+	}
+	return ans
+}
+
+// Inverse returns the permutation which maps `p[i]` to `i`.
+func (p Permutation) Inverse() Permutation {
+	ans := make(Permutation, len(p))
+	for i, pi := range p {
+		ans[pi] = i
+	}
+	return ans
+}
+
+// Equal reports whether the permutations are the same.
+func (p Permutation) Equal(q Permutation) bool {
+	return equalInts(p, q)
+}
+
+// CycleDecomposition returns the cycles of the permutation, including
+// the fixed points as cycles of length 1. Each cycle starts from its
+// smallest number, and the cycles are in the order of the smallest numbers.
+// The cycle [a b c] maps a to b, b to c and c to a.
+func (p Permutation) CycleDecomposition() [][]int {
+	cycles := [][]int{}
+	visited := make([]bool, len(p))
+	for start := range p {
+		if visited[start] {
+			continue
+		}
+		cycle := []int{}
+		for i := start; !visited[i]; i = p[i] {
+			visited[i] = true
+			cycle = append(cycle, i)
+		}
+		cycles = append(cycles, cycle)
+	}
+	return cycles
+}
+
+// CycleCount returns the number of the cycles, including the fixed points.
+func (p Permutation) CycleCount() int {
+	count := 0
+	visited := make([]bool, len(p))
+	for start := range p {
+		if visited[start] {
+			continue
+		}
+		for i := start; !visited[i]; i = p[i] {
+			visited[i] = true
+		}
+		count++
+	}
+	return count
+}
+
+// CycleType returns the lengths of the cycles in descending order,
+// including the fixed points.
+func (p Permutation) CycleType() []int {
+	counts := make([]int, len(p)+1) // counts[l] is the number of l-cycles
+	for _, cycle := range p.CycleDecomposition() {
+		counts[len(cycle)]++
+	}
+	lengths := []int{}
+	for l := len(p); l >= 1; l-- {
+		for i := 0; i < counts[l]; i++ {
+			lengths = append(lengths, l)
+		}
+	}
+	return lengths
+}
+
+// Parity returns 0 for an even permutation and 1 for an odd one, which is
+// the parity of the number of transpositions composing it.
+func (p Permutation) Parity() int {
+	// A cycle of length l is composed of l-1 transpositions.
+	return (len(p) - p.CycleCount()) % 2
+}
+
+// Sign returns 1 for an even permutation and -1 for an odd one.
+func (p Permutation) Sign() int {
+	return 1 - 2*p.Parity()
+}
+
+// Order returns the smallest positive number of times to compose
+// the permutation into the identity, which is the least common multiple of
+// the lengths of the cycles.
+func (p Permutation) Order() int {
+	order := 1
+	for _, cycle := range p.CycleDecomposition() {
+		order = order / gcd(order, len(cycle)) * len(cycle)
+	}
+	return order
+}
+
+// FixedPoints returns the numbers mapped to themselves in ascending order.
+func (p Permutation) FixedPoints() []int {
+	points := []int{}
+	for i, pi := range p {
+		if i == pi {
+			points = append(points, i)
+		}
+	}
+	return points
+}
+
+// ApplyPermutation returns a new slice in which the element at `i` of `a`
+// moves to `p[i]`. Applying `p` and then `q` is the same as applying
+// `q.Compose(p)`. It panics if the lengths differ.
+func ApplyPermutation[T any](p Permutation, a []T) []T {
+	if len(p) != len(a) {
+		panic("combinatorics: applying a permutation to a slice of a different length")
+	}
+	ans := make([]T, len(a))
+	for i, pi := range p {
+		ans[pi] = a[i]
+	}
+	return ans
+}
+
+// String returns the cycle notation of the permutation like "(0 2 1)(3 4)",
+// which omits the fixed points. The identity is "()".
+func (p Permutation) String() string {
+	var sb strings.Builder
+	for _, cycle := range p.CycleDecomposition() {
+		if len(cycle) == 1 {
+			continue
+		}
+		sb.WriteByte('(')
+		for i, num := range cycle {
+			if i > 0 {
+				sb.WriteByte(' ')
+			}
+			sb.WriteString(strconv.Itoa(num))
+		}
+		sb.WriteByte(')')
+	}
+	if sb.Len() == 0 {
+		return "()"
+	}
+	return sb.String()
+}
+
+// ParsePermutation parses the cycle notation of a permutation of `n`
+// numbers like "(0 2 1)(3 4)". The numbers not in the cycles are fixed
+// points. The cycles must be disjoint, and may be separated by spaces.
+// Numbers in a cycle may also be separated by commas.
+func ParsePermutation(s string, n int) (Permutation, error) {
+	if n < 0 {
+		return nil, ErrNegative
+	}
+	p := IdentityPermutation(n)
+	used := make([]bool, n)
+
+	rest := strings.TrimSpace(s)
+	for rest != "" {
+		if rest[0] != '(' {
+			return nil, fmt.Errorf("combinatorics: invalid cycle notation %q: want '('", s)
+		}
+		end := strings.IndexByte(rest, ')')
+		if end < 0 {
+			return nil, fmt.Errorf("combinatorics: invalid cycle notation %q: want ')'", s)
+		}
+		fields := strings.FieldsFunc(rest[1:end], func(r rune) bool {
+			return r == ' ' || r == ',' || r == '\t'
+		})
+		rest = strings.TrimSpace(rest[end+1:])
+
+		cycle := make([]int, len(fields))
+		for i, field := range fields {
+			num, err := strconv.Atoi(field)
+			if err != nil {
+				return nil, fmt.Errorf("combinatorics: invalid cycle notation %q: %v", s, err)
+			}
+			if num < 0 || num >= n {
+				return nil, fmt.Errorf("combinatorics: invalid cycle notation %q: %d is out of range for n=%d", s, num, n)
+			}
+			if used[num] {
+				return nil, fmt.Errorf("combinatorics: invalid cycle notation %q: %d appears twice", s, num)
+			}
+			used[num] = true
+			cycle[i] = num
+		}
+		for i, num := range cycle {
+			p[num] = cycle[(i+1)%len(cycle)]
+		}
+	}
+	return p, nil
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
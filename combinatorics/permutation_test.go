@@ -0,0 +1,225 @@
+package combinatorics
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// allPermutations collects the permutations of `n` numbers by
+// PermutationsRecursive6.
+func allPermutations(n int) []Permutation {
+	perms := []Permutation{}
+	PermutationsRecursive6(n, n, func(pattern []int) {
+		perms = append(perms, append(Permutation{}, pattern...))
+	})
+	return perms
+}
+
+func TestPermutation(t *testing.T) {
+	p, err := ParsePermutation("(0 2 1)(3 4)", 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (Permutation{2, 0, 1, 4, 3, 5}); !p.Equal(want) {
+		t.Errorf("want: %v, got: %v", []int(want), []int(p))
+	}
+	if got := p.String(); got != "(0 2 1)(3 4)" {
+		t.Errorf("String: got %q", got)
+	}
+	if want := [][]int{{0, 2, 1}, {3, 4}, {5}}; !reflect.DeepEqual(p.CycleDecomposition(), want) {
+		t.Errorf("CycleDecomposition: want: %v, got: %v", want, p.CycleDecomposition())
+	}
+	if want := []int{3, 2, 1}; !reflect.DeepEqual(p.CycleType(), want) {
+		t.Errorf("CycleType: want: %v, got: %v", want, p.CycleType())
+	}
+	if p.Parity() != 1 || p.Sign() != -1 {
+		t.Errorf("want odd, got parity %d and sign %d", p.Parity(), p.Sign())
+	}
+	if p.Order() != 6 {
+		t.Errorf("Order: want 6, got %d", p.Order())
+	}
+	if want := []int{5}; !reflect.DeepEqual(p.FixedPoints(), want) {
+		t.Errorf("FixedPoints: want: %v, got: %v", want, p.FixedPoints())
+	}
+	if want := []string{"b", "c", "a", "e", "d", "f"}; !reflect.DeepEqual(
+		ApplyPermutation(p, []string{"a", "b", "c", "d", "e", "f"}), want) {
+		t.Errorf("ApplyPermutation: want: %v", want)
+	}
+
+	if got := IdentityPermutation(3).String(); got != "()" {
+		t.Errorf("identity: got %q", got)
+	}
+	if got := IdentityPermutation(0); got.Order() != 1 || got.Sign() != 1 {
+		t.Errorf("empty: got order %d and sign %d", got.Order(), got.Sign())
+	}
+}
+
+func TestParsePermutation(t *testing.T) {
+	valid := map[string]Permutation{
+		"":                {0, 1, 2, 3},
+		"()":              {0, 1, 2, 3},
+		" (1) (0, 3 2) ":  {3, 1, 0, 2},
+		"(3 0)(2 1)":      {3, 2, 1, 0},
+		"(0 1 2 3)":       {1, 2, 3, 0},
+		"(0 1)()(2)(3)()": {1, 0, 2, 3},
+	}
+	for s, want := range valid {
+		got, err := ParsePermutation(s, 4)
+		if err != nil {
+			t.Errorf("%q: %v", s, err)
+			continue
+		}
+		if !got.Equal(want) {
+			t.Errorf("%q: want: %v, got: %v", s, []int(want), []int(got))
+		}
+	}
+
+	for _, s := range []string{"0 1", "(0 1", "(0 a)", "(0 4)", "(-1 0)", "(0 1)(1 2)", "(0 0)", "(0 1)x"} {
+		if _, err := ParsePermutation(s, 4); err == nil {
+			t.Errorf("%q: want an error", s)
+		}
+	}
+	if _, err := ParsePermutation("()", -1); err != ErrNegative {
+		t.Errorf("want ErrNegative, got %v", err)
+	}
+}
+
+func TestNewPermutation(t *testing.T) {
+	a := []int{1, 2, 0}
+	p, err := NewPermutation(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a[0] = 9
+	if p[0] != 1 {
+		t.Error("want a copy")
+	}
+
+	for _, a := range [][]int{{0, 0}, {1, 2}, {-1, 0}} {
+		if _, err := NewPermutation(a); err != ErrNotPermutation {
+			t.Errorf("%v: want ErrNotPermutation, got %v", a, err)
+		}
+	}
+}
+
+func TestPermutationGroupLaws(t *testing.T) {
+	for n := 0; n <= 6; n++ {
+		perms := allPermutations(n)
+		id := IdentityPermutation(n)
+
+		evens := 0
+		for _, p := range perms {
+			if !p.Compose(id).Equal(p) || !id.Compose(p).Equal(p) {
+				t.Fatalf("n=%d %v: the identity is not neutral", n, p)
+			}
+			inv := p.Inverse()
+			if !p.Compose(inv).Equal(id) || !inv.Compose(p).Equal(id) {
+				t.Fatalf("n=%d %v: the inverse %v", n, p, inv)
+			}
+			if !inv.Inverse().Equal(p) {
+				t.Fatalf("n=%d %v: the inverse is not an involution", n, p)
+			}
+
+			// the order
+			power := p
+			for i := 1; i < p.Order(); i++ {
+				if power.Equal(id) {
+					t.Fatalf("n=%d %v: the order is not %d but %d", n, p, p.Order(), i)
+				}
+				power = p.Compose(power)
+			}
+			if !power.Equal(id) {
+				t.Fatalf("n=%d %v: the %d-th power is not the identity", n, p, p.Order())
+			}
+
+			if p.Sign() != inv.Sign() {
+				t.Fatalf("n=%d %v: the sign of the inverse differs", n, p)
+			}
+			if p.Parity() == 0 {
+				evens++
+			}
+
+			// cycle notation
+			parsed, err := ParsePermutation(p.String(), n)
+			if err != nil || !parsed.Equal(p) {
+				t.Fatalf("n=%d %v: parsed %q into %v, %v", n, []int(p), p.String(), parsed, err)
+			}
+
+			// the cycles cover all the numbers
+			total := 0
+			for _, l := range p.CycleType() {
+				total += l
+			}
+			if total != n || len(p.CycleType()) != p.CycleCount() {
+				t.Fatalf("n=%d %v: the cycle type %v", n, p, p.CycleType())
+			}
+			if len(p.FixedPoints()) != countOnes(p.CycleType()) {
+				t.Fatalf("n=%d %v: the fixed points %v", n, p, p.FixedPoints())
+			}
+		}
+		if n >= 2 && evens != len(perms)/2 {
+			t.Errorf("n=%d: want %d even permutations, got %d", n, len(perms)/2, evens)
+		}
+
+		if n > 5 {
+			continue
+		}
+		for _, p := range perms {
+			for _, q := range perms {
+				pq := p.Compose(q)
+				if !FamilyPermutations.validPattern(n, pq) {
+					t.Fatalf("n=%d: %v∘%v = %v is not a permutation", n, p, q, pq)
+				}
+				if pq.Sign() != p.Sign()*q.Sign() {
+					t.Fatalf("n=%d: the sign of %v∘%v", n, p, q)
+				}
+				if !pq.Inverse().Equal(q.Inverse().Compose(p.Inverse())) {
+					t.Fatalf("n=%d: the inverse of %v∘%v", n, p, q)
+				}
+				a := ApplyPermutation(pq, numbers(n))
+				if !equalInts(a, ApplyPermutation(p, ApplyPermutation(q, numbers(n)))) {
+					t.Fatalf("n=%d: applying %v∘%v", n, p, q)
+				}
+			}
+		}
+	}
+}
+
+func TestPermutationAssociativity(t *testing.T) {
+	for n := 0; n <= 4; n++ {
+		perms := allPermutations(n)
+		for _, p := range perms {
+			for _, q := range perms {
+				for _, r := range perms {
+					if !p.Compose(q).Compose(r).Equal(p.Compose(q.Compose(r))) {
+						t.Fatalf("n=%d: (%v∘%v)∘%v", n, p, q, r)
+					}
+				}
+			}
+		}
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	for n := 5; n <= 6; n++ {
+		perms := allPermutations(n)
+		for trial := 0; trial < 10000; trial++ {
+			p := perms[rnd.Intn(len(perms))]
+			q := perms[rnd.Intn(len(perms))]
+			r := perms[rnd.Intn(len(perms))]
+			if !p.Compose(q).Compose(r).Equal(p.Compose(q.Compose(r))) {
+				t.Fatalf("n=%d: (%v∘%v)∘%v", n, p, q, r)
+			}
+		}
+	}
+}
+
+func countOnes(a []int) int {
+	count := 0
+	for _, v := range a {
+		if v == 1 {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,97 @@
+package combinatorics
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// propertyLimits are the largest `n` and `k` of each family to check
+// the properties in a moment, since Recursive0 is slow.
+var propertyLimits = map[Family]benchSize{
+	FamilyCombinations:    {n: 8, k: 8},
+	FamilyDupCombinations: {n: 6, k: 6},
+	FamilyPermutations:    {n: 6, k: 6},
+	FamilyDupPermutations: {n: 4, k: 5},
+}
+
+// checkProperties checks that all the implementations of the family produce
+// the identical sequence, which is strictly increasing in lexicographic
+// order, consists of valid patterns, and has as many patterns as the formula.
+func checkProperties(t *testing.T, family Family, n, k int) {
+	t.Helper()
+
+	var want [][]int
+	for i, impl := range Implementations(family) {
+		got := collectPatterns(t, impl, n, k)
+		if i == 0 {
+			want = got
+			continue
+		}
+		if !reflect.DeepEqual(got, want) && !(len(got) == 0 && len(want) == 0) {
+			t.Fatalf("%s n=%d k=%d: differs from %s",
+				impl.FullName(), n, k, Implementations(family)[0].FullName())
+		}
+	}
+
+	if count := family.Count(n, k); len(want) != count {
+		t.Errorf("%s n=%d k=%d: want %d patterns, got: %d", family, n, k, count, len(want))
+	}
+	for i, pattern := range want {
+		if len(pattern) != k || !family.validPattern(n, pattern) {
+			t.Fatalf("%s n=%d k=%d: invalid pattern: %v", family, n, k, pattern)
+		}
+		if i > 0 && !lexLess(want[i-1], pattern) {
+			t.Fatalf("%s n=%d k=%d: not strictly increasing: %v, %v",
+				family, n, k, want[i-1], pattern)
+		}
+	}
+}
+
+func TestProperties(t *testing.T) {
+	const trials = 50
+
+	r := rand.New(rand.NewSource(1))
+	for _, family := range Families {
+		t.Run(family.String(), func(t *testing.T) {
+			limit := propertyLimits[family]
+			for try := 0; try < trials; try++ {
+				n := r.Intn(limit.n + 1)
+				k := r.Intn(limit.k + 1)
+				checkProperties(t, family, n, k)
+			}
+		})
+	}
+}
+
+func fuzzFamily(f *testing.F, family Family) {
+	limit := propertyLimits[family]
+	for n := 0; n <= 3; n++ {
+		for k := 0; k <= 3; k++ {
+			f.Add(uint8(n), uint8(k))
+		}
+	}
+	f.Add(uint8(limit.n), uint8(limit.k))
+
+	f.Fuzz(func(t *testing.T, nByte, kByte uint8) {
+		n := int(nByte) % (limit.n + 1)
+		k := int(kByte) % (limit.k + 1)
+		checkProperties(t, family, n, k)
+	})
+}
+
+func FuzzCombinations(f *testing.F) {
+	fuzzFamily(f, FamilyCombinations)
+}
+
+func FuzzDupCombinations(f *testing.F) {
+	fuzzFamily(f, FamilyDupCombinations)
+}
+
+func FuzzPermutations(f *testing.F) {
+	fuzzFamily(f, FamilyPermutations)
+}
+
+func FuzzDupPermutations(f *testing.F) {
+	fuzzFamily(f, FamilyDupPermutations)
+}
@@ -0,0 +1,148 @@
+package combinatorics
+
+// CombinationRank computes the index of the combination in lexicographic
+// order.
+func CombinationRank(n int, pattern []int) int {
+	k := len(pattern)
+
+	rank := 0
+	begin := 0
+	for pos, num := range pattern {
+		// skip the combinations with smaller numbers in the digit
+		for smaller := begin; smaller < num; smaller++ {
+			rank += CombinationCount(n-smaller-1, k-pos-1)
+		}
+		begin = num + 1
+	}
+	return rank
+}
+
+// CombinationUnrank fills `pattern` with the combination of the index in
+// lexicographic order. It is the inverse of CombinationRank.
+func CombinationUnrank(n, rank int, pattern []int) {
+	k := len(pattern)
+
+	num := 0
+	for pos := range pattern {
+		for {
+			count := CombinationCount(n-num-1, k-pos-1)
+			if rank < count {
+				break
+			}
+			rank -= count
+			num++
+		}
+		pattern[pos] = num
+		num++
+	}
+}
+
+// DupCombinationRank computes the index of the combination with repetition
+// in lexicographic order.
+func DupCombinationRank(n int, pattern []int) int {
+	k := len(pattern)
+
+	rank := 0
+	begin := 0
+	for pos, num := range pattern {
+		// skip the combinations with smaller numbers in the digit
+		for smaller := begin; smaller < num; smaller++ {
+			rank += DupCombinationCount(n-smaller, k-pos-1)
+		}
+		begin = num
+	}
+	return rank
+}
+
+// DupCombinationUnrank fills `pattern` with the combination with repetition
+// of the index in lexicographic order. It is the inverse of
+// DupCombinationRank.
+func DupCombinationUnrank(n, rank int, pattern []int) {
+	k := len(pattern)
+
+	num := 0
+	for pos := range pattern {
+		for {
+			count := DupCombinationCount(n-num, k-pos-1)
+			if rank < count {
+				break
+			}
+			rank -= count
+			num++
+		}
+		pattern[pos] = num
+	}
+}
+
+// PermutationRank computes the index of the permutation in lexicographic
+// order.
+func PermutationRank(n int, pattern []int) int {
+	k := len(pattern)
+
+	rank := 0
+	for pos, num := range pattern {
+		// count the available numbers smaller than `num`
+		smallerCount := num
+		for i := 0; i < pos; i++ {
+			if pattern[i] < num {
+				smallerCount--
+			}
+		}
+
+		rank += smallerCount * PermutationCount(n-pos-1, k-pos-1)
+	}
+	return rank
+}
+
+// PermutationUnrank fills `pattern` with the permutation of the index in
+// lexicographic order. It is the inverse of PermutationRank.
+func PermutationUnrank(n, rank int, pattern []int) {
+	k := len(pattern)
+
+	for pos := range pattern {
+		count := PermutationCount(n-pos-1, k-pos-1)
+		index := rank / count
+		rank %= count
+
+		// choose the `index`-th available number
+		for num := 0; num < n; num++ {
+			// skip if the number of `num` is used in the left digits
+			willContinue := false
+			for i := 0; i < pos; i++ {
+				if pattern[i] == num {
+					willContinue = true
+					break
+				}
+			}
+			if willContinue {
+				continue
+			}
+
+			if index == 0 {
+				pattern[pos] = num
+				break
+			}
+			index--
+		}
+	}
+}
+
+// DupPermutationRank computes the index of the permutation with repetition
+// in lexicographic order, which is the base-n number of the permutation.
+func DupPermutationRank(n int, pattern []int) int {
+	rank := 0
+	for _, num := range pattern {
+		rank = rank*n + num
+	}
+	return rank
+}
+
+// DupPermutationUnrank fills `pattern` with the permutation with repetition
+// of the index in lexicographic order. It is the inverse of
+// DupPermutationRank.
+func DupPermutationUnrank(n, rank int, pattern []int) {
+	for pos := len(pattern) - 1; pos >= 0; pos-- {
+		pattern[pos] = rank % n
+		rank /= n
+	}
+}
@@ -0,0 +1,58 @@
+package combinatorics
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestRanks(t *testing.T) {
+	targets := []struct {
+		name   string
+		each   func(n, k int, f func([]int))
+		rank   func(n int, pattern []int) int
+		unrank func(n, rank int, pattern []int)
+	}{
+		{"Combination", CombinationsWithCarrying0,
+			CombinationRank, CombinationUnrank},
+		{"DupCombination", DupCombinationsWithCarrying0,
+			DupCombinationRank, DupCombinationUnrank},
+		{"Permutation", PermutationsWithCarrying1,
+			PermutationRank, PermutationUnrank},
+		{"DupPermutation", DupPermutationsWithCarrying0,
+			DupPermutationRank, DupPermutationUnrank},
+	}
+
+	cases := []struct {
+		n, k int
+	}{
+		{n: 0, k: 0},
+		{n: 3, k: 0},
+		{n: 3, k: 1},
+		{n: 4, k: 4},
+		{n: 6, k: 3},
+	}
+
+	for _, target := range targets {
+		t.Run(target.name, func(t *testing.T) {
+			for _, c := range cases {
+				t.Run(fmt.Sprintf("n=%d k=%d", c.n, c.k), func(t *testing.T) {
+					want := 0
+					target.each(c.n, c.k, func(pattern []int) {
+						if got := target.rank(c.n, pattern); got != want {
+							t.Errorf("rank of %v: want: %d, got: %d", pattern, want, got)
+						}
+
+						got := make([]int, c.k)
+						target.unrank(c.n, want, got)
+						if !reflect.DeepEqual(got, pattern) {
+							t.Errorf("unrank of %d: want: %v, got: %v", want, pattern, got)
+						}
+
+						want++
+					})
+				})
+			}
+		})
+	}
+}
@@ -0,0 +1,384 @@
+package combinatorics
+
+import (
+	"runtime"
+	"sync"
+)
+
+// Accumulator folds patterns in a parallel reduction.
+type Accumulator interface {
+	// Add folds a pattern. `rank` is the index of the pattern in lexicographic
+	// order. The pattern is reused by the enumeration, so copy it to keep it.
+	Add(rank int, pattern []int)
+
+	// Merge folds the result of another accumulator, which has the same
+	// concrete type as the receiver.
+	Merge(other Accumulator)
+}
+
+// ReduceCombinations folds all combinations in parallel. The work is split by
+// the number of the first digit, and each piece is folded by a new accumulator
+// on one of `workers` goroutines (GOMAXPROCS if `workers` is not positive).
+// The accumulators are merged into a new one in lexicographic order, so the
+// result does not depend on scheduling.
+func ReduceCombinations(n, k, workers int, newAccumulator func() Accumulator) Accumulator {
+	return reduce(k, n-k+1, workers, newAccumulator,
+		func(first int) int {
+			return CombinationCount(n-first-1, k-1)
+		},
+		func(first int, f func([]int)) {
+			combinationsWithFirst(n, k, first, f)
+		})
+}
+
+// ReduceDupCombinations folds all combinations with repetition in parallel.
+// See ReduceCombinations for details.
+func ReduceDupCombinations(n, k, workers int, newAccumulator func() Accumulator) Accumulator {
+	return reduce(k, n, workers, newAccumulator,
+		func(first int) int {
+			return DupCombinationCount(n-first, k-1)
+		},
+		func(first int, f func([]int)) {
+			dupCombinationsWithFirst(n, k, first, f)
+		})
+}
+
+// ReducePermutations folds all permutations in parallel.
+// See ReduceCombinations for details.
+func ReducePermutations(n, k, workers int, newAccumulator func() Accumulator) Accumulator {
+	if k > n {
+		return newAccumulator()
+	}
+
+	return reduce(k, n, workers, newAccumulator,
+		func(first int) int {
+			return PermutationCount(n-1, k-1)
+		},
+		func(first int, f func([]int)) {
+			permutationsWithFirst(n, k, first, f)
+		})
+}
+
+// ReduceDupPermutations folds all permutations with repetition in parallel.
+// See ReduceCombinations for details.
+func ReduceDupPermutations(n, k, workers int, newAccumulator func() Accumulator) Accumulator {
+	return reduce(k, n, workers, newAccumulator,
+		func(first int) int {
+			return DupPermutationCount(n, k-1)
+		},
+		func(first int, f func([]int)) {
+			dupPermutationsWithFirst(n, k, first, f)
+		})
+}
+
+// CountAccumulator counts patterns satisfying `Pred`.
+type CountAccumulator struct {
+	Pred  func([]int) bool
+	Count int
+}
+
+// Add ...
+func (acc *CountAccumulator) Add(rank int, pattern []int) {
+	if acc.Pred(pattern) {
+		acc.Count++
+	}
+}
+
+// Merge ...
+func (acc *CountAccumulator) Merge(other Accumulator) {
+	acc.Count += other.(*CountAccumulator).Count
+}
+
+// SumAccumulator sums up the scores of patterns.
+type SumAccumulator struct {
+	Score func([]int) float64
+	Sum   float64
+}
+
+// Add ...
+func (acc *SumAccumulator) Add(rank int, pattern []int) {
+	acc.Sum += acc.Score(pattern)
+}
+
+// Merge ...
+func (acc *SumAccumulator) Merge(other Accumulator) {
+	acc.Sum += other.(*SumAccumulator).Sum
+}
+
+// MinAccumulator finds the pattern with the minimum cost. A tie is broken by
+// the rank, that is, the lexicographically smallest pattern wins.
+type MinAccumulator struct {
+	Cost    func([]int) float64
+	Found   bool
+	Pattern []int
+	Value   float64
+	Rank    int
+}
+
+// Add ...
+func (acc *MinAccumulator) Add(rank int, pattern []int) {
+	acc.update(rank, acc.Cost(pattern), pattern)
+}
+
+// Merge ...
+func (acc *MinAccumulator) Merge(other Accumulator) {
+	o := other.(*MinAccumulator)
+	if o.Found {
+		acc.update(o.Rank, o.Value, o.Pattern)
+	}
+}
+
+func (acc *MinAccumulator) update(rank int, value float64, pattern []int) {
+	if acc.Found &&
+		!(value < acc.Value || value == acc.Value && rank < acc.Rank) {
+		return
+	}
+
+	acc.Found = true
+	acc.Pattern = append(acc.Pattern[:0], pattern...)
+	acc.Value = value
+	acc.Rank = rank
+}
+
+// MaxAccumulator finds the pattern with the maximum score. A tie is broken by
+// the rank, that is, the lexicographically smallest pattern wins.
+type MaxAccumulator struct {
+	Score   func([]int) float64
+	Found   bool
+	Pattern []int
+	Value   float64
+	Rank    int
+}
+
+// Add ...
+func (acc *MaxAccumulator) Add(rank int, pattern []int) {
+	acc.update(rank, acc.Score(pattern), pattern)
+}
+
+// Merge ...
+func (acc *MaxAccumulator) Merge(other Accumulator) {
+	o := other.(*MaxAccumulator)
+	if o.Found {
+		acc.update(o.Rank, o.Value, o.Pattern)
+	}
+}
+
+func (acc *MaxAccumulator) update(rank int, value float64, pattern []int) {
+	if acc.Found &&
+		!(value > acc.Value || value == acc.Value && rank < acc.Rank) {
+		return
+	}
+
+	acc.Found = true
+	acc.Pattern = append(acc.Pattern[:0], pattern...)
+	acc.Value = value
+	acc.Rank = rank
+}
+
+// reduce folds patterns grouped by the number of the first digit.
+// `count(first)` is the number of patterns in a group, and `each(first, f)`
+// enumerates them in lexicographic order.
+func reduce(
+	k, firstCount, workers int,
+	newAccumulator func() Accumulator,
+	count func(first int) int,
+	each func(first int, f func([]int)),
+) Accumulator {
+	if k == 0 {
+		acc := newAccumulator()
+		acc.Add(0, []int{})
+		return acc
+	}
+	if firstCount < 0 {
+		firstCount = 0
+	}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	// the rank of the first pattern in each group
+	offsets := make([]int, firstCount)
+	{
+		offset := 0
+		for first := range offsets {
+			offsets[first] = offset
+			offset += count(first)
+		}
+	}
+
+	accs := make([]Accumulator, firstCount)
+	firsts := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for first := range firsts {
+				acc := newAccumulator()
+				rank := offsets[first]
+				each(first, func(pattern []int) {
+					acc.Add(rank, pattern)
+					rank++
+				})
+				accs[first] = acc
+			}
+		}()
+	}
+	for first := 0; first < firstCount; first++ {
+		firsts <- first
+	}
+	close(firsts)
+	wg.Wait()
+
+	// merge in lexicographic order not to depend on scheduling
+	ans := newAccumulator()
+	for _, acc := range accs {
+		ans.Merge(acc)
+	}
+	return ans
+}
+
+// combinationsWithFirst bases on CombinationsWithCarrying1, but it keeps
+// the first digit.
+func combinationsWithFirst(n, k, first int, f func([]int)) {
+	pattern := make([]int, k)
+	pattern[0] = first
+	for i := 1; i < k; i++ {
+		pattern[i] = -1
+	}
+
+	pos := 1
+	for pos > 0 {
+		if pos == k {
+			f(pattern)
+			pos--
+			continue
+		}
+
+		// carry
+		oldNum := pattern[pos]
+		if oldNum == n+pos-k {
+			pattern[pos] = -1
+			pos--
+			continue
+		}
+
+		if oldNum == -1 {
+			// replace the number of the carried digit
+			pattern[pos] = pattern[pos-1] + 1
+		} else {
+			// increment
+			pattern[pos]++
+		}
+		pos++
+	}
+}
+
+// dupCombinationsWithFirst bases on DupCombinationsWithCarrying1, but it keeps
+// the first digit.
+func dupCombinationsWithFirst(n, k, first int, f func([]int)) {
+	pattern := make([]int, k)
+	pattern[0] = first
+	for i := 1; i < k; i++ {
+		pattern[i] = -1
+	}
+
+	pos := 1
+	for pos > 0 {
+		if pos == k {
+			f(pattern)
+			pos--
+			continue
+		}
+
+		// carry
+		oldNum := pattern[pos]
+		if oldNum == n-1 {
+			pattern[pos] = -1
+			pos--
+			continue
+		}
+
+		if oldNum == -1 {
+			// replace the number of the carried digit
+			pattern[pos] = pattern[pos-1]
+		} else {
+			// increment
+			pattern[pos]++
+		}
+		pos++
+	}
+}
+
+// permutationsWithFirst bases on PermutationsWithCarrying2, but it keeps
+// the first digit.
+func permutationsWithFirst(n, k, first int, f func([]int)) {
+	checklist := make([]bool, n)
+	pattern := make([]int, k)
+	pattern[0] = first
+	checklist[first] = true
+	for i := 1; i < k; i++ {
+		pattern[i] = -1
+	}
+
+	pos := 1
+	for pos > 0 {
+		if pos == k {
+			f(pattern)
+			pos--
+			continue
+		}
+
+		oldNum := pattern[pos]
+		if oldNum > -1 {
+			checklist[oldNum] = false
+		}
+
+		willContinue := false
+		for newNum := oldNum + 1; newNum < n; newNum++ {
+			if checklist[newNum] {
+				continue
+			}
+
+			pattern[pos] = newNum
+			checklist[newNum] = true
+			pos++
+			willContinue = true
+			break
+		}
+		if willContinue {
+			continue
+		}
+
+		// carry
+		pattern[pos] = -1
+		pos--
+	}
+}
+
+// dupPermutationsWithFirst bases on DupPermutationsWithCarrying1, but it keeps
+// the first digit.
+func dupPermutationsWithFirst(n, k, first int, f func([]int)) {
+	pattern := make([]int, k)
+	pattern[0] = first
+
+	pos := k
+	for pos > 0 {
+		if pos == k {
+			f(pattern)
+			pos--
+			continue
+		}
+
+		// carry
+		oldNum := pattern[pos]
+		if oldNum == n-1 {
+			pattern[pos] = 0
+			pos--
+			continue
+		}
+
+		pattern[pos]++
+		pos = k
+	}
+}
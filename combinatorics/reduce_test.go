@@ -0,0 +1,164 @@
+package combinatorics
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+type collectAccumulator struct {
+	ranks    []int
+	patterns [][]int
+}
+
+func (acc *collectAccumulator) Add(rank int, pattern []int) {
+	patternClone := make([]int, len(pattern))
+	copy(patternClone, pattern)
+	acc.ranks = append(acc.ranks, rank)
+	acc.patterns = append(acc.patterns, patternClone)
+}
+
+func (acc *collectAccumulator) Merge(other Accumulator) {
+	o := other.(*collectAccumulator)
+	acc.ranks = append(acc.ranks, o.ranks...)
+	acc.patterns = append(acc.patterns, o.patterns...)
+}
+
+var reduceTargets = []struct {
+	name   string
+	reduce func(n, k, workers int, newAccumulator func() Accumulator) Accumulator
+	each   func(n, k int, f func([]int))
+}{
+	{"Combinations", ReduceCombinations, CombinationsWithCarrying0},
+	{"DupCombinations", ReduceDupCombinations, DupCombinationsWithCarrying0},
+	{"Permutations", ReducePermutations, PermutationsWithCarrying1},
+	{"DupPermutations", ReduceDupPermutations, DupPermutationsWithCarrying0},
+}
+
+var reduceCases = []struct {
+	n, k int
+}{
+	{n: 0, k: 0},
+	{n: 3, k: 0},
+	{n: 3, k: 1},
+	{n: 4, k: 4},
+	{n: 6, k: 3},
+}
+
+func TestReduce(t *testing.T) {
+	for _, target := range reduceTargets {
+		t.Run(target.name, func(t *testing.T) {
+			for _, c := range reduceCases {
+				want := [][]int{}
+				target.each(c.n, c.k, func(pattern []int) {
+					patternClone := make([]int, len(pattern))
+					copy(patternClone, pattern)
+					want = append(want, patternClone)
+				})
+
+				for _, workers := range []int{0, 1, 3} {
+					t.Run(fmt.Sprintf("n=%d k=%d workers=%d", c.n, c.k, workers), func(t *testing.T) {
+						acc := target.reduce(c.n, c.k, workers,
+							func() Accumulator {
+								return &collectAccumulator{}
+							}).(*collectAccumulator)
+
+						if !reflect.DeepEqual(acc.patterns, want) {
+							t.Errorf("want: %v, got: %v", want, acc.patterns)
+						}
+						for i, rank := range acc.ranks {
+							if rank != i {
+								t.Errorf("rank of %v: want: %d, got: %d", acc.patterns[i], i, rank)
+								break
+							}
+						}
+					})
+				}
+			}
+		})
+	}
+}
+
+func TestReduceWithKExceedingN(t *testing.T) {
+	for _, target := range reduceTargets {
+		if target.name == "DupCombinations" || target.name == "DupPermutations" {
+			continue
+		}
+
+		t.Run(target.name, func(t *testing.T) {
+			acc := target.reduce(2, 3, 0, func() Accumulator {
+				return &CountAccumulator{Pred: func([]int) bool { return true }}
+			}).(*CountAccumulator)
+			if acc.Count != 0 {
+				t.Errorf("want: 0, got: %d", acc.Count)
+			}
+		})
+	}
+}
+
+func TestAccumulators(t *testing.T) {
+	const n = 7
+	const k = 4
+
+	// many ties, so that the winner is decided by the rank
+	score := func(pattern []int) float64 {
+		return float64(pattern[0] + pattern[len(pattern)-1])
+	}
+
+	for _, target := range reduceTargets {
+		t.Run(target.name, func(t *testing.T) {
+			wantCount := 0
+			wantSum := 0.0
+			wantMin := &MinAccumulator{Cost: score}
+			wantMax := &MaxAccumulator{Score: score}
+			{
+				rank := 0
+				target.each(n, k, func(pattern []int) {
+					if pattern[0] < pattern[len(pattern)-1] {
+						wantCount++
+					}
+					wantSum += score(pattern)
+					wantMin.Add(rank, pattern)
+					wantMax.Add(rank, pattern)
+					rank++
+				})
+			}
+
+			count := target.reduce(n, k, 0, func() Accumulator {
+				return &CountAccumulator{Pred: func(pattern []int) bool {
+					return pattern[0] < pattern[len(pattern)-1]
+				}}
+			}).(*CountAccumulator)
+			if count.Count != wantCount {
+				t.Errorf("count: want: %d, got: %d", wantCount, count.Count)
+			}
+
+			sum := target.reduce(n, k, 0, func() Accumulator {
+				return &SumAccumulator{Score: score}
+			}).(*SumAccumulator)
+			if sum.Sum != wantSum {
+				t.Errorf("sum: want: %v, got: %v", wantSum, sum.Sum)
+			}
+
+			min := target.reduce(n, k, 0, func() Accumulator {
+				return &MinAccumulator{Cost: score}
+			}).(*MinAccumulator)
+			if min.Rank != wantMin.Rank || min.Value != wantMin.Value ||
+				!reflect.DeepEqual(min.Pattern, wantMin.Pattern) {
+				t.Errorf("min: want: %d %v %v, got: %d %v %v",
+					wantMin.Rank, wantMin.Value, wantMin.Pattern,
+					min.Rank, min.Value, min.Pattern)
+			}
+
+			max := target.reduce(n, k, 0, func() Accumulator {
+				return &MaxAccumulator{Score: score}
+			}).(*MaxAccumulator)
+			if max.Rank != wantMax.Rank || max.Value != wantMax.Value ||
+				!reflect.DeepEqual(max.Pattern, wantMax.Pattern) {
+				t.Errorf("max: want: %d %v %v, got: %d %v %v",
+					wantMax.Rank, wantMax.Value, wantMax.Pattern,
+					max.Rank, max.Value, max.Pattern)
+			}
+		})
+	}
+}
@@ -0,0 +1,400 @@
+package combinatorics
+
+import "sort"
+
+// Family is a kind of patterns to enumerate.
+type Family int
+
+const (
+	// FamilyCombinations is the family of combinations.
+	FamilyCombinations Family = iota
+	// FamilyDupCombinations is the family of combinations with repetition.
+	FamilyDupCombinations
+	// FamilyPermutations is the family of permutations.
+	FamilyPermutations
+	// FamilyDupPermutations is the family of permutations with repetition.
+	FamilyDupPermutations
+)
+
+// Families lists all the families.
+var Families = []Family{
+	FamilyCombinations,
+	FamilyDupCombinations,
+	FamilyPermutations,
+	FamilyDupPermutations,
+}
+
+func (family Family) String() string {
+	switch family {
+	case FamilyCombinations:
+		return "Combinations"
+	case FamilyDupCombinations:
+		return "DupCombinations"
+	case FamilyPermutations:
+		return "Permutations"
+	case FamilyDupPermutations:
+		return "DupPermutations"
+	default:
+		return "Family(?)"
+	}
+}
+
+// Count computes the number of patterns.
+func (family Family) Count(n, k int) int {
+	switch family {
+	case FamilyCombinations:
+		return CombinationCount(n, k)
+	case FamilyDupCombinations:
+		return DupCombinationCount(n, k)
+	case FamilyPermutations:
+		return PermutationCount(n, k)
+	case FamilyDupPermutations:
+		return DupPermutationCount(n, k)
+	default:
+		panic("combinatorics: unknown family")
+	}
+}
+
+// validPattern reports whether the pattern consists of numbers from 0 to
+// `n-1` with the constraint of the family.
+func (family Family) validPattern(n int, pattern []int) bool {
+	var scratch []int
+	if family == FamilyPermutations {
+		scratch = make([]int, len(pattern))
+	}
+	return family.validPatternIn(n, pattern, scratch)
+}
+
+// validPatternIn is validPattern which finds duplicates of a permutation by
+// sorting the numbers in `scratch`, whose length is of the pattern, so that
+// it takes no memory of `n`.
+func (family Family) validPatternIn(n int, pattern, scratch []int) bool {
+	for i, num := range pattern {
+		if num < 0 || num >= n {
+			return false
+		}
+		switch family {
+		case FamilyCombinations:
+			if i > 0 && pattern[i-1] >= num {
+				return false
+			}
+		case FamilyDupCombinations:
+			if i > 0 && pattern[i-1] > num {
+				return false
+			}
+		}
+	}
+
+	if family == FamilyPermutations {
+		copy(scratch, pattern)
+		sort.Ints(scratch)
+		for i := 1; i < len(scratch); i++ {
+			if scratch[i-1] == scratch[i] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Signature is a kind of the signature of an implementation.
+type Signature int
+
+const (
+	// SignatureCallback is `func(n, k int, f func([]int))`.
+	SignatureCallback Signature = iota
+	// SignatureSliceReturning is `func(n, k int) [][]int`.
+	SignatureSliceReturning
+	// SignatureRangeSliceReturning is `func(begin, end, k int) [][]int`.
+	SignatureRangeSliceReturning
+	// SignatureABasedCallback is `func(a []int, k int, f func([]int))`.
+	SignatureABasedCallback
+	// SignatureABasedSliceReturning is `func(a []int, k int) [][]int`.
+	SignatureABasedSliceReturning
+)
+
+func (signature Signature) String() string {
+	switch signature {
+	case SignatureCallback:
+		return "Callback"
+	case SignatureSliceReturning:
+		return "SliceReturning"
+	case SignatureRangeSliceReturning:
+		return "RangeSliceReturning"
+	case SignatureABasedCallback:
+		return "ABasedCallback"
+	case SignatureABasedSliceReturning:
+		return "ABasedSliceReturning"
+	default:
+		return "Signature(?)"
+	}
+}
+
+// StackShape is how an implementation uses its stack.
+type StackShape int
+
+const (
+	// NoStack is of implementations without a stack.
+	NoStack StackShape = iota
+	// CallStack is of implementations which push an item for each digit
+	// like a recursive call, e.g. PermutationsWithStack0.
+	CallStack
+	// NodeStack is of implementations which push all the children of a node
+	// of the pattern tree at once, starting from the sentinel node,
+	// e.g. PermutationsWithStack3.
+	NodeStack
+	// RootlessNodeStack is like NodeStack, but starts from the nodes for
+	// the first digit, e.g. PermutationsWithStack4.
+	RootlessNodeStack
+	// OperationStack is of implementations which push three operations for
+	// each node, e.g. PermutationsWithStack7.
+	OperationStack
+)
+
+// Implementation describes an implementation of enumeration.
+type Implementation struct {
+	Family Family
+	Name   string      // the name without the family, e.g. "WithCarrying0"
+	Func   interface{} // the function, e.g. CombinationsWithCarrying0
+	Stack  StackShape
+}
+
+// FullName returns the name of the function, e.g. "CombinationsWithCarrying0".
+func (impl Implementation) FullName() string {
+	return impl.Family.String() + impl.Name
+}
+
+// Signature returns the kind of the signature of the function.
+func (impl Implementation) Signature() Signature {
+	switch impl.Func.(type) {
+	case func(n, k int, f func([]int)):
+		return SignatureCallback
+	case func(n, k int) [][]int:
+		return SignatureSliceReturning
+	case func(begin, end, k int) [][]int:
+		return SignatureRangeSliceReturning
+	case func(a []int, k int, f func([]int)):
+		return SignatureABasedCallback
+	case func(a []int, k int) [][]int:
+		return SignatureABasedSliceReturning
+	default:
+		panic("combinatorics: unknown signature of " + impl.FullName())
+	}
+}
+
+// Each enumerates patterns of numbers from 0 to `n-1` with the implementation.
+// The same memory space may be reused for each pattern.
+func (impl Implementation) Each(n, k int, f func([]int)) {
+	switch fn := impl.Func.(type) {
+	case func(n, k int, f func([]int)):
+		fn(n, k, f)
+	case func(n, k int) [][]int:
+		for _, pattern := range fn(n, k) {
+			f(pattern)
+		}
+	case func(begin, end, k int) [][]int:
+		for _, pattern := range fn(0, n, k) {
+			f(pattern)
+		}
+	case func(a []int, k int, f func([]int)):
+		fn(numbers(n), k, f)
+	case func(a []int, k int) [][]int:
+		for _, pattern := range fn(numbers(n), k) {
+			f(pattern)
+		}
+	default:
+		panic("combinatorics: unknown signature of " + impl.FullName())
+	}
+}
+
+// Collect returns all the patterns enumerated by the implementation. Each
+// pattern has its own memory space.
+func (impl Implementation) Collect(n, k int) [][]int {
+	switch fn := impl.Func.(type) {
+	case func(n, k int) [][]int:
+		return fn(n, k)
+	case func(begin, end, k int) [][]int:
+		return fn(0, n, k)
+	case func(a []int, k int) [][]int:
+		return fn(numbers(n), k)
+	}
+
+	got := [][]int{}
+	impl.Each(n, k, func(pattern []int) {
+		patternClone := make([]int, len(pattern))
+		copy(patternClone, pattern)
+		got = append(got, patternClone)
+	})
+	return got
+}
+
+// StackStats computes the number of pushes and the max depth of the stack
+// in an enumeration by the implementation, from the shape of the tree of
+// patterns. It returns zeros for an implementation without a stack.
+func (impl Implementation) StackStats(n, k int) (pushes, maxDepth int) {
+	if impl.Stack == NoStack || impl.Family.Count(n, k) == 0 {
+		return 0, 0
+	}
+
+	// the number of nodes for the first `depth` digits
+	nodeCount := func(depth int) int {
+		switch impl.Family {
+		case FamilyCombinations:
+			return CombinationCount(n-k+depth, depth)
+		case FamilyDupCombinations:
+			return DupCombinationCount(n, depth)
+		case FamilyPermutations:
+			return PermutationCount(n, depth)
+		default:
+			return DupPermutationCount(n, depth)
+		}
+	}
+	// the number of children of the most left node for the first `depth`
+	// digits, which has the most children and the most right siblings
+	childCount := func(depth int) int {
+		switch impl.Family {
+		case FamilyCombinations:
+			return n - k + 1
+		case FamilyPermutations:
+			return n - depth
+		default:
+			return n
+		}
+	}
+
+	nodes := 0
+	for depth := 1; depth <= k; depth++ {
+		nodes += nodeCount(depth)
+	}
+	// the stack size after pushing the children of the most left nodes
+	leftChildren := 0
+	for depth := 0; depth < k; depth++ {
+		leftChildren += childCount(depth)
+	}
+
+	switch impl.Stack {
+	case CallStack:
+		return 1 + nodes, k + 1
+	case NodeStack:
+		if k == 0 {
+			return 1, 1
+		}
+		return 1 + nodes, leftChildren - (k - 1)
+	case RootlessNodeStack:
+		if k == 0 {
+			return 0, 0
+		}
+		return nodes, leftChildren - (k - 1)
+	case OperationStack:
+		if k == 0 {
+			return 1, 1
+		}
+		return 1 + 3*nodes, 3*leftChildren - 2*(k-1)
+	default:
+		panic("combinatorics: unknown stack shape")
+	}
+}
+
+var implementations = []Implementation{
+	{FamilyCombinations, "Recursive0", CombinationsRecursive0, NoStack},
+	{FamilyCombinations, "Recursive1", CombinationsRecursive1, NoStack},
+	{FamilyCombinations, "Recursive2", CombinationsRecursive2, NoStack},
+	{FamilyCombinations, "WithStack0", CombinationsWithStack0, NodeStack},
+	{FamilyCombinations, "WithSlice0", CombinationsWithSlice0, NodeStack},
+	{FamilyCombinations, "WithStackPooled0", CombinationsWithStackPooled0, NodeStack},
+	{FamilyCombinations, "WithCarrying0", CombinationsWithCarrying0, NoStack},
+	{FamilyCombinations, "WithCarrying1", CombinationsWithCarrying1, NoStack},
+
+	{FamilyDupCombinations, "Recursive0", DupCombinationsRecursive0, NoStack},
+	{FamilyDupCombinations, "Recursive1", DupCombinationsRecursive1, NoStack},
+	{FamilyDupCombinations, "Recursive2", DupCombinationsRecursive2, NoStack},
+	{FamilyDupCombinations, "WithStack0", DupCombinationsWithStack0, NodeStack},
+	{FamilyDupCombinations, "WithSlice0", DupCombinationsWithSlice0, NodeStack},
+	{FamilyDupCombinations, "WithStackPooled0", DupCombinationsWithStackPooled0, NodeStack},
+	{FamilyDupCombinations, "WithCarrying0", DupCombinationsWithCarrying0, NoStack},
+	{FamilyDupCombinations, "WithCarrying1", DupCombinationsWithCarrying1, NoStack},
+
+	{FamilyPermutations, "Recursive0", PermutationsRecursive0, NoStack},
+	{FamilyPermutations, "Recursive1", PermutationsRecursive1, NoStack},
+	{FamilyPermutations, "Recursive2", PermutationsRecursive2, NoStack},
+	{FamilyPermutations, "Recursive3", PermutationsRecursive3, NoStack},
+	{FamilyPermutations, "Recursive4", PermutationsRecursive4, NoStack},
+	{FamilyPermutations, "Recursive5", PermutationsRecursive5, NoStack},
+	{FamilyPermutations, "Recursive6", PermutationsRecursive6, NoStack},
+	{FamilyPermutations, "Recursive7", PermutationsRecursive7, NoStack},
+	{FamilyPermutations, "WithStack0", PermutationsWithStack0, CallStack},
+	{FamilyPermutations, "WithStack1", PermutationsWithStack1, CallStack},
+	{FamilyPermutations, "WithStack2", PermutationsWithStack2, NodeStack},
+	{FamilyPermutations, "WithStack3", PermutationsWithStack3, NodeStack},
+	{FamilyPermutations, "WithStack4", PermutationsWithStack4, RootlessNodeStack},
+	{FamilyPermutations, "WithStack5", PermutationsWithStack5, NodeStack},
+	{FamilyPermutations, "WithStack6", PermutationsWithStack6, NodeStack},
+	{FamilyPermutations, "WithStack7", PermutationsWithStack7, OperationStack},
+	{FamilyPermutations, "WithStack8", PermutationsWithStack8, OperationStack},
+	{FamilyPermutations, "WithSlice0", PermutationsWithSlice0, CallStack},
+	{FamilyPermutations, "WithSlice1", PermutationsWithSlice1, CallStack},
+	{FamilyPermutations, "WithSlice2", PermutationsWithSlice2, NodeStack},
+	{FamilyPermutations, "WithSlice4", PermutationsWithSlice4, RootlessNodeStack},
+	{FamilyPermutations, "WithSlice5", PermutationsWithSlice5, NodeStack},
+	{FamilyPermutations, "WithSlice6", PermutationsWithSlice6, NodeStack},
+	{FamilyPermutations, "WithSlice7", PermutationsWithSlice7, OperationStack},
+	{FamilyPermutations, "WithSlice8", PermutationsWithSlice8, OperationStack},
+	{FamilyPermutations, "WithStackPooled0", PermutationsWithStackPooled0, CallStack},
+	{FamilyPermutations, "WithStackPooled1", PermutationsWithStackPooled1, CallStack},
+	{FamilyPermutations, "WithStackPooled2", PermutationsWithStackPooled2, NodeStack},
+	{FamilyPermutations, "WithStackPooled3", PermutationsWithStackPooled3, NodeStack},
+	{FamilyPermutations, "WithStackPooled4", PermutationsWithStackPooled4, RootlessNodeStack},
+	{FamilyPermutations, "WithStackPooled5", PermutationsWithStackPooled5, NodeStack},
+	{FamilyPermutations, "WithStackPooled6", PermutationsWithStackPooled6, NodeStack},
+	{FamilyPermutations, "WithStackPooled7", PermutationsWithStackPooled7, OperationStack},
+	{FamilyPermutations, "WithStackPooled8", PermutationsWithStackPooled8, OperationStack},
+	{FamilyPermutations, "WithCarrying0", PermutationsWithCarrying0, NoStack},
+	{FamilyPermutations, "WithCarrying1", PermutationsWithCarrying1, NoStack},
+	{FamilyPermutations, "WithCarrying2", PermutationsWithCarrying2, NoStack},
+
+	{FamilyDupPermutations, "Recursive0", DupPermutationsRecursive0, NoStack},
+	{FamilyDupPermutations, "Recursive1", DupPermutationsRecursive1, NoStack},
+	{FamilyDupPermutations, "WithStack0", DupPermutationsWithStack0, NodeStack},
+	{FamilyDupPermutations, "WithSlice0", DupPermutationsWithSlice0, NodeStack},
+	{FamilyDupPermutations, "WithStackPooled0", DupPermutationsWithStackPooled0, NodeStack},
+	{FamilyDupPermutations, "WithCarrying0", DupPermutationsWithCarrying0, NoStack},
+	{FamilyDupPermutations, "WithCarrying1", DupPermutationsWithCarrying1, NoStack},
+	{FamilyDupPermutations, "WithBaseConverting0", DupPermutationsWithBaseConverting0, NoStack},
+}
+
+// Implementations returns the registered implementations of the family.
+func Implementations(family Family) []Implementation {
+	ans := []Implementation{}
+	for _, impl := range implementations {
+		if impl.Family == family {
+			ans = append(ans, impl)
+		}
+	}
+	return ans
+}
+
+// AllImplementations returns all the registered implementations.
+func AllImplementations() []Implementation {
+	ans := make([]Implementation, len(implementations))
+	copy(ans, implementations)
+	return ans
+}
+
+// LookupImplementation finds the registered implementation by the family and
+// the name, e.g. `LookupImplementation(FamilyCombinations, "WithCarrying0")`.
+func LookupImplementation(family Family, name string) (Implementation, bool) {
+	for _, impl := range implementations {
+		if impl.Family == family && impl.Name == name {
+			return impl, true
+		}
+	}
+	return Implementation{}, false
+}
+
+func numbers(n int) []int {
+	a := make([]int, n)
+	for i := 0; i < n; i++ {
+		a[i] = i
+	}
+	return a
+}
@@ -0,0 +1,96 @@
+package combinatorics
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestImplementations(t *testing.T) {
+	fullNames := map[string]bool{}
+	for _, impl := range AllImplementations() {
+		if fullNames[impl.FullName()] {
+			t.Errorf("registered twice: %s", impl.FullName())
+		}
+		fullNames[impl.FullName()] = true
+
+		got, ok := LookupImplementation(impl.Family, impl.Name)
+		if !ok || got.FullName() != impl.FullName() {
+			t.Errorf("lookup %s: got: %v %v", impl.FullName(), got.FullName(), ok)
+		}
+	}
+
+	for _, family := range Families {
+		if len(Implementations(family)) == 0 {
+			t.Errorf("no implementations of %v", family)
+		}
+	}
+}
+
+func TestImplementationCounts(t *testing.T) {
+	cases := []struct {
+		n, k int
+	}{
+		{n: 0, k: 0},
+		{n: 4, k: 2},
+		{n: 5, k: 5},
+	}
+
+	for _, impl := range AllImplementations() {
+		t.Run(impl.FullName(), func(t *testing.T) {
+			for _, c := range cases {
+				t.Run(fmt.Sprintf("n=%d k=%d", c.n, c.k), func(t *testing.T) {
+					want := impl.Family.Count(c.n, c.k)
+
+					got := 0
+					impl.Each(c.n, c.k, func([]int) {
+						got++
+					})
+					if got != want {
+						t.Errorf("Each: want: %d, got: %d", want, got)
+					}
+
+					if got := len(impl.Collect(c.n, c.k)); got != want {
+						t.Errorf("Collect: want: %d, got: %d", want, got)
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestStackStats(t *testing.T) {
+	// counted by hand, following the implementations
+	cases := []struct {
+		family       Family
+		name         string
+		n, k         int
+		wantPushes   int
+		wantMaxDepth int
+	}{
+		{FamilyCombinations, "WithCarrying0", 4, 2, 0, 0},
+		{FamilyCombinations, "WithStack0", 4, 2, 10, 5},
+		{FamilyCombinations, "WithSlice0", 4, 0, 1, 1},
+		{FamilyDupCombinations, "WithStack0", 3, 2, 10, 5},
+		{FamilyDupPermutations, "WithStack0", 2, 3, 15, 4},
+		{FamilyPermutations, "WithStack0", 3, 2, 10, 3},
+		{FamilyPermutations, "WithSlice1", 3, 0, 1, 1},
+		{FamilyPermutations, "WithStack3", 3, 2, 10, 4},
+		{FamilyPermutations, "WithStack4", 3, 2, 9, 4},
+		{FamilyPermutations, "WithSlice4", 3, 0, 0, 0},
+		{FamilyPermutations, "WithStack7", 3, 2, 28, 13},
+		{FamilyPermutations, "WithSlice8", 3, 3, 1 + 3*15, 3*6 - 4},
+	}
+
+	for _, c := range cases {
+		impl, ok := LookupImplementation(c.family, c.name)
+		if !ok {
+			t.Fatalf("not found: %v %s", c.family, c.name)
+		}
+
+		pushes, maxDepth := impl.StackStats(c.n, c.k)
+		if pushes != c.wantPushes || maxDepth != c.wantMaxDepth {
+			t.Errorf("%s n=%d k=%d: want: %d %d, got: %d %d", impl.FullName(), c.n, c.k,
+				c.wantPushes, c.wantMaxDepth, pushes, maxDepth)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package combinatorics
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// RandomPermutation chooses a permutation uniformly at random. It shuffles
+// only the first `k` items of numbers with Fisher–Yates shuffle. It fails
+// like Family.Validate for negative `n` or `k` and for `k > n`.
+func RandomPermutation(r *rand.Rand, n, k int) ([]int, error) {
+	if err := validateSample(FamilyPermutations, n, k); err != nil {
+		return nil, err
+	}
+
+	a := make([]int, n)
+	for i := range a {
+		a[i] = i
+	}
+
+	for i := 0; i < k; i++ {
+		j := i + r.Intn(n-i)
+		a[i], a[j] = a[j], a[i]
+	}
+	return a[:k], nil
+}
+
+// RandomCombination chooses a combination uniformly at random with Floyd's
+// algorithm, which draws only `k` random numbers. It fails like
+// Family.Validate for negative `n` or `k` and for `k > n`.
+func RandomCombination(r *rand.Rand, n, k int) ([]int, error) {
+	if err := validateSample(FamilyCombinations, n, k); err != nil {
+		return nil, err
+	}
+	return randomCombination(r, n, k), nil
+}
+
+func randomCombination(r *rand.Rand, n, k int) []int {
+	chosen := make(map[int]bool, k)
+	pattern := make([]int, 0, k)
+	for max := n - k; max < n; max++ {
+		num := r.Intn(max + 1)
+		if chosen[num] {
+			num = max
+		}
+		chosen[num] = true
+		pattern = append(pattern, num)
+	}
+
+	sort.Ints(pattern)
+	return pattern
+}
+
+// RandomDupCombination chooses a combination with repetition uniformly at
+// random. It maps a combination of `k` numbers from `n+k-1` numbers into
+// a combination with repetition by subtracting the position from each digit.
+// It fails for negative `n` or `k`, and for `n = 0` and `k > 0` with
+// ErrNoPatterns.
+func RandomDupCombination(r *rand.Rand, n, k int) ([]int, error) {
+	if err := validateSample(FamilyDupCombinations, n, k); err != nil {
+		return nil, err
+	}
+
+	pattern := randomCombination(r, n+k-1, k)
+	for pos := range pattern {
+		pattern[pos] -= pos
+	}
+	return pattern, nil
+}
+
+// RandomDupPermutation chooses a permutation with repetition uniformly at
+// random. It fails like RandomDupCombination.
+func RandomDupPermutation(r *rand.Rand, n, k int) ([]int, error) {
+	if err := validateSample(FamilyDupPermutations, n, k); err != nil {
+		return nil, err
+	}
+
+	pattern := make([]int, k)
+	for pos := range pattern {
+		pattern[pos] = r.Intn(n)
+	}
+	return pattern, nil
+}
+
+// validateSample checks `n` and `k` like Family.Validate, except that it
+// accepts a number of patterns overflowing int, which samplers do not count,
+// and rejects sizes without patterns to choose.
+func validateSample(family Family, n, k int) error {
+	if err := family.validateSize(n, k); err != nil {
+		return err
+	}
+	if n == 0 && k > 0 {
+		return &SizeError{family, n, k, ErrNoPatterns}
+	}
+	return nil
+}
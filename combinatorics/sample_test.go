@@ -0,0 +1,121 @@
+package combinatorics
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestRandomSamplers(t *testing.T) {
+	targets := []struct {
+		name   string
+		sample func(r *rand.Rand, n, k int) ([]int, error)
+		each   func(n, k int, f func([]int))
+	}{
+		{"Permutation", RandomPermutation, PermutationsWithCarrying1},
+		{"Combination", RandomCombination, CombinationsWithCarrying0},
+		{"DupCombination", RandomDupCombination, DupCombinationsWithCarrying0},
+		{"DupPermutation", RandomDupPermutation, DupPermutationsWithCarrying0},
+	}
+
+	cases := []struct {
+		n, k int
+	}{
+		{n: 3, k: 0},
+		{n: 3, k: 1},
+		{n: 4, k: 4},
+		{n: 5, k: 2},
+		{n: 6, k: 3},
+	}
+
+	const trialsPerPattern = 200
+
+	for _, target := range targets {
+		t.Run(target.name, func(t *testing.T) {
+			for _, c := range cases {
+				t.Run(fmt.Sprintf("n=%d k=%d", c.n, c.k), func(t *testing.T) {
+					observed := map[string]int{}
+					target.each(c.n, c.k, func(pattern []int) {
+						observed[fmt.Sprint(pattern)] = 0
+					})
+
+					r := rand.New(rand.NewSource(1))
+					trials := trialsPerPattern * len(observed)
+					for try := 0; try < trials; try++ {
+						pattern, err := target.sample(r, c.n, c.k)
+						if err != nil {
+							t.Fatal(err)
+						}
+						key := fmt.Sprint(pattern)
+						if _, ok := observed[key]; !ok {
+							t.Fatalf("invalid pattern: %v", pattern)
+						}
+						observed[key]++
+					}
+
+					if len(observed) == 1 {
+						return
+					}
+
+					// Pearson's chi-square test at the significance level 0.001
+					expected := float64(trialsPerPattern)
+					chiSquare := 0.0
+					for _, count := range observed {
+						diff := float64(count) - expected
+						chiSquare += diff * diff / expected
+					}
+					df := float64(len(observed) - 1)
+					if critical := chiSquareCritical(df); chiSquare > critical {
+						t.Errorf("not uniform: chi-square: %.2f > %.2f (df=%v)",
+							chiSquare, critical, df)
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestRandomSamplerErrors(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	cases := []struct {
+		name   string
+		sample func(r *rand.Rand, n, k int) ([]int, error)
+		n, k   int
+		want   error
+	}{
+		{"Permutation", RandomPermutation, 3, 4, ErrKExceedsN},
+		{"Permutation", RandomPermutation, -1, 0, ErrNegative},
+		{"Combination", RandomCombination, 3, 4, ErrKExceedsN},
+		{"Combination", RandomCombination, 3, -1, ErrNegative},
+		{"DupCombination", RandomDupCombination, 0, 2, ErrNoPatterns},
+		{"DupPermutation", RandomDupPermutation, 0, 2, ErrNoPatterns},
+		{"DupPermutation", RandomDupPermutation, -2, 2, ErrNegative},
+	}
+	for _, c := range cases {
+		if _, err := c.sample(r, c.n, c.k); !errors.Is(err, c.want) {
+			t.Errorf("%s n=%d k=%d: want %v, got %v", c.name, c.n, c.k, c.want, err)
+		}
+	}
+
+	// The samplers do not count patterns, which overflow int here.
+	if pattern, err := RandomPermutation(r, 100, 100); err != nil || len(pattern) != 100 {
+		t.Errorf("n=100 k=100: got %v, %v", pattern, err)
+	}
+	for _, sample := range []func(r *rand.Rand, n, k int) ([]int, error){
+		RandomDupCombination, RandomDupPermutation,
+	} {
+		if pattern, err := sample(r, 0, 0); err != nil || len(pattern) != 0 {
+			t.Errorf("n=0 k=0: got %v, %v", pattern, err)
+		}
+	}
+}
+
+// chiSquareCritical approximates the upper 0.001 point of the chi-square
+// distribution by Wilson–Hilferty transformation.
+func chiSquareCritical(df float64) float64 {
+	const z = 3.090 // the upper 0.001 point of the standard normal distribution
+	a := 2 / (9 * df)
+	return df * math.Pow(1-a+z*math.Sqrt(a), 3)
+}
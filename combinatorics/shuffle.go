@@ -0,0 +1,108 @@
+package combinatorics
+
+// CombinationsInRandomOrder enumerates each combination exactly once in
+// a pseudo-random order decided by `seed`. It shuffles indexes of
+// lexicographic order and unranks them.
+func CombinationsInRandomOrder(n, k int, seed int64, f func([]int)) {
+	eachInRandomOrder(CombinationCount(n, k), seed, k,
+		func(rank int, pattern []int) {
+			CombinationUnrank(n, rank, pattern)
+		}, f)
+}
+
+// DupCombinationsInRandomOrder enumerates each combination with repetition
+// exactly once in a pseudo-random order decided by `seed`.
+func DupCombinationsInRandomOrder(n, k int, seed int64, f func([]int)) {
+	eachInRandomOrder(DupCombinationCount(n, k), seed, k,
+		func(rank int, pattern []int) {
+			DupCombinationUnrank(n, rank, pattern)
+		}, f)
+}
+
+// PermutationsInRandomOrder enumerates each permutation exactly once in
+// a pseudo-random order decided by `seed`.
+func PermutationsInRandomOrder(n, k int, seed int64, f func([]int)) {
+	eachInRandomOrder(PermutationCount(n, k), seed, k,
+		func(rank int, pattern []int) {
+			PermutationUnrank(n, rank, pattern)
+		}, f)
+}
+
+// DupPermutationsInRandomOrder enumerates each permutation with repetition
+// exactly once in a pseudo-random order decided by `seed`.
+func DupPermutationsInRandomOrder(n, k int, seed int64, f func([]int)) {
+	eachInRandomOrder(DupPermutationCount(n, k), seed, k,
+		func(rank int, pattern []int) {
+			DupPermutationUnrank(n, rank, pattern)
+		}, f)
+}
+
+func eachInRandomOrder(
+	count int, seed int64, k int,
+	unrank func(rank int, pattern []int),
+	f func([]int),
+) {
+	shuffler := newRankShuffler(count, seed)
+	pattern := make([]int, k)
+	for i := 0; i < count; i++ {
+		unrank(shuffler.shuffle(i), pattern)
+		f(pattern)
+	}
+}
+
+const rankShufflerRounds = 4
+
+// rankShuffler is a bijection on [0, count). It is a balanced Feistel network
+// on the smallest domain of 2^(2*halfBits) covering [0, count), and it walks
+// the cycle until the value comes back into [0, count).
+type rankShuffler struct {
+	count    uint64
+	halfBits uint
+	mask     uint64
+	keys     [rankShufflerRounds]uint64
+}
+
+func newRankShuffler(count int, seed int64) *rankShuffler {
+	halfBits := uint(1)
+	for halfBits < 32 && uint64(1)<<(2*halfBits) < uint64(count) {
+		halfBits++
+	}
+
+	s := rankShuffler{
+		count:    uint64(count),
+		halfBits: halfBits,
+		mask:     uint64(1)<<halfBits - 1,
+	}
+	state := uint64(seed)
+	for i := range s.keys {
+		state += 0x9e3779b97f4a7c15
+		s.keys[i] = mix64(state)
+	}
+	return &s
+}
+
+func (s *rankShuffler) shuffle(rank int) int {
+	x := uint64(rank)
+	for {
+		x = s.encrypt(x)
+		if x < s.count {
+			return int(x)
+		}
+	}
+}
+
+func (s *rankShuffler) encrypt(x uint64) uint64 {
+	left := x >> s.halfBits
+	right := x & s.mask
+	for _, key := range s.keys {
+		left, right = right, left^(mix64(right^key)&s.mask)
+	}
+	return left<<s.halfBits | right
+}
+
+// mix64 is the finalizer of SplitMix64.
+func mix64(x uint64) uint64 {
+	x = (x ^ x>>30) * 0xbf58476d1ce4e5b9
+	x = (x ^ x>>27) * 0x94d049bb133111eb
+	return x ^ x>>31
+}
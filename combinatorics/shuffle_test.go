@@ -0,0 +1,105 @@
+package combinatorics
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestInRandomOrder(t *testing.T) {
+	targets := []struct {
+		name     string
+		each     func(n, k int, f func([]int))
+		shuffled func(n, k int, seed int64, f func([]int))
+	}{
+		{"Combinations", CombinationsWithCarrying0,
+			CombinationsInRandomOrder},
+		{"DupCombinations", DupCombinationsWithCarrying0,
+			DupCombinationsInRandomOrder},
+		{"Permutations", PermutationsWithCarrying1,
+			PermutationsInRandomOrder},
+		{"DupPermutations", DupPermutationsWithCarrying0,
+			DupPermutationsInRandomOrder},
+	}
+
+	cases := []struct {
+		n, k int
+	}{
+		{n: 0, k: 0},
+		{n: 3, k: 0},
+		{n: 3, k: 1},
+		{n: 4, k: 4},
+		{n: 7, k: 3},
+	}
+
+	collect := func(each func(f func([]int))) [][]int {
+		got := [][]int{}
+		each(func(pattern []int) {
+			patternClone := make([]int, len(pattern))
+			copy(patternClone, pattern)
+			got = append(got, patternClone)
+		})
+		return got
+	}
+
+	for _, target := range targets {
+		t.Run(target.name, func(t *testing.T) {
+			for _, c := range cases {
+				t.Run(fmt.Sprintf("n=%d k=%d", c.n, c.k), func(t *testing.T) {
+					want := collect(func(f func([]int)) {
+						target.each(c.n, c.k, f)
+					})
+
+					for _, seed := range []int64{0, 1, 42} {
+						got := collect(func(f func([]int)) {
+							target.shuffled(c.n, c.k, seed, f)
+						})
+
+						// each pattern appears exactly once
+						appeared := map[string]int{}
+						for _, pattern := range got {
+							appeared[fmt.Sprint(pattern)]++
+						}
+						for _, pattern := range want {
+							if count := appeared[fmt.Sprint(pattern)]; count != 1 {
+								t.Errorf("seed=%d: %v appeared %d times", seed, pattern, count)
+							}
+						}
+						if len(got) != len(want) {
+							t.Errorf("seed=%d: want %d patterns, got %d", seed, len(want), len(got))
+						}
+
+						// the order is shuffled unless too few
+						if len(want) > 5 && reflect.DeepEqual(got, want) {
+							t.Errorf("seed=%d: not shuffled: %v", seed, got)
+						}
+
+						// the same seed, the same order
+						again := collect(func(f func([]int)) {
+							target.shuffled(c.n, c.k, seed, f)
+						})
+						if !reflect.DeepEqual(again, got) {
+							t.Errorf("seed=%d: not reproducible", seed)
+						}
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestRankShuffler(t *testing.T) {
+	for _, count := range []int{0, 1, 2, 3, 17, 64, 1000} {
+		t.Run(fmt.Sprintf("count=%d", count), func(t *testing.T) {
+			shuffler := newRankShuffler(count, 7)
+			seen := make([]bool, count)
+			for i := 0; i < count; i++ {
+				got := shuffler.shuffle(i)
+				if got < 0 || got >= count || seen[got] {
+					t.Fatalf("not a bijection: %d -> %d", i, got)
+				}
+				seen[got] = true
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package combinatorics
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+var (
+	// ErrNegative means that `n` or `k` is negative.
+	ErrNegative = errors.New("negative n or k")
+	// ErrKExceedsN means that `k` exceeds `n` for a family without
+	// repetition.
+	ErrKExceedsN = errors.New("k exceeds n")
+	// ErrCountOverflow means that the number of patterns overflows int.
+	ErrCountOverflow = errors.New("count of patterns overflows int")
+	// ErrNoPatterns means that there are no patterns to choose, for `n = 0`
+	// and `k > 0`.
+	ErrNoPatterns = errors.New("no patterns")
+)
+
+// SizeError is an error of the size of a family. It wraps ErrNegative,
+// ErrKExceedsN, ErrCountOverflow or ErrNoPatterns, which can be tested by
+// errors.Is.
+type SizeError struct {
+	Family Family
+	N, K   int
+	Err    error
+}
+
+func (e *SizeError) Error() string {
+	return fmt.Sprintf("combinatorics: %s of n=%d, k=%d: %v", e.Family, e.N, e.K, e.Err)
+}
+
+func (e *SizeError) Unwrap() error {
+	return e.Err
+}
+
+// Combinations enumerates combinations with CombinationsWithCarrying0 after
+// validating `n` and `k`.
+func Combinations(n, k int, f func([]int)) error {
+	if err := FamilyCombinations.Validate(n, k); err != nil {
+		return err
+	}
+	CombinationsWithCarrying0(n, k, f)
+	return nil
+}
+
+// DupCombinations enumerates combinations with repetition with
+// DupCombinationsWithCarrying0 after validating `n` and `k`.
+func DupCombinations(n, k int, f func([]int)) error {
+	if err := FamilyDupCombinations.Validate(n, k); err != nil {
+		return err
+	}
+	DupCombinationsWithCarrying0(n, k, f)
+	return nil
+}
+
+// Permutations enumerates permutations with PermutationsRecursive6 after
+// validating `n` and `k`.
+func Permutations(n, k int, f func([]int)) error {
+	if err := FamilyPermutations.Validate(n, k); err != nil {
+		return err
+	}
+	PermutationsRecursive6(n, k, f)
+	return nil
+}
+
+// DupPermutations enumerates permutations with repetition with
+// DupPermutationsWithCarrying0 after validating `n` and `k`.
+func DupPermutations(n, k int, f func([]int)) error {
+	if err := FamilyDupPermutations.Validate(n, k); err != nil {
+		return err
+	}
+	DupPermutationsWithCarrying0(n, k, f)
+	return nil
+}
+
+// Validate checks that `n` and `k` are not negative, that `k` does not exceed
+// `n` for the families without repetition, and that the number of patterns
+// fits in int. The error is a *SizeError.
+func (family Family) Validate(n, k int) error {
+	_, err := family.CheckedCount(n, k)
+	return err
+}
+
+// CheckedCount computes the number of patterns after validating `n` and `k`
+// like Validate.
+func (family Family) CheckedCount(n, k int) (int, error) {
+	if err := family.validateSize(n, k); err != nil {
+		return 0, err
+	}
+
+	var count int
+	var ok bool
+	switch family {
+	case FamilyCombinations:
+		count, ok = checkedCombinationCount(n, k)
+	case FamilyDupCombinations:
+		count, ok = checkedDupCombinationCount(n, k)
+	case FamilyPermutations:
+		count, ok = checkedPermutationCount(n, k)
+	case FamilyDupPermutations:
+		count, ok = checkedDupPermutationCount(n, k)
+	default:
+		panic("combinatorics: unknown family")
+	}
+	if !ok {
+		return 0, &SizeError{family, n, k, ErrCountOverflow}
+	}
+	return count, nil
+}
+
+// validateSize checks `n` and `k` like Validate, except that it accepts
+// a number of patterns overflowing int.
+func (family Family) validateSize(n, k int) error {
+	switch {
+	case n < 0 || k < 0:
+		return &SizeError{family, n, k, ErrNegative}
+	case k > n && (family == FamilyCombinations || family == FamilyPermutations):
+		return &SizeError{family, n, k, ErrKExceedsN}
+	}
+	return nil
+}
+
+// checkedCombinationCount computes C(n, k) for `0 <= k <= n` by 128-bit
+// intermediate products. C(n, i) increases until `i = k` since `k` is
+// replaced by `n-k` if it is larger, so it overflows only at last.
+func checkedCombinationCount(n, k int) (int, bool) {
+	if n-k < k {
+		k = n - k
+	}
+
+	ans := uint64(1)
+	for i := 0; i < k; i++ {
+		hi, lo := bits.Mul64(ans, uint64(n-i))
+		divisor := uint64(i + 1)
+		if hi >= divisor {
+			return 0, false
+		}
+		ans, _ = bits.Div64(hi, lo, divisor)
+		if ans > math.MaxInt {
+			return 0, false
+		}
+	}
+	return int(ans), true
+}
+
+func checkedDupCombinationCount(n, k int) (int, bool) {
+	if k == 0 {
+		return 1, true
+	}
+	if n == 0 {
+		return 0, true
+	}
+	if n-1 > math.MaxInt-k {
+		return 0, false
+	}
+	return checkedCombinationCount(n+k-1, k)
+}
+
+func checkedPermutationCount(n, k int) (int, bool) {
+	ans := 1
+	for i := 0; i < k; i++ {
+		var ok bool
+		if ans, ok = checkedMul(ans, n-i); !ok {
+			return 0, false
+		}
+	}
+	return ans, true
+}
+
+func checkedDupPermutationCount(n, k int) (int, bool) {
+	if k == 0 || n == 1 {
+		return 1, true
+	}
+	if n == 0 {
+		return 0, true
+	}
+
+	ans := 1
+	for i := 0; i < k; i++ {
+		var ok bool
+		if ans, ok = checkedMul(ans, n); !ok {
+			return 0, false
+		}
+	}
+	return ans, true
+}
+
+// checkedAdd adds non-negative numbers.
+func checkedAdd(a, b int) (int, bool) {
+	if a > math.MaxInt-b {
+		return 0, false
+	}
+	return a + b, true
+}
+
+// checkedMul multiplies non-negative numbers.
+func checkedMul(a, b int) (int, bool) {
+	hi, lo := bits.Mul64(uint64(a), uint64(b))
+	if hi != 0 || lo > math.MaxInt {
+		return 0, false
+	}
+	return int(lo), true
+}
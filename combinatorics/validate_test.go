@@ -0,0 +1,196 @@
+package combinatorics
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		family Family
+		n, k   int
+		want   error
+	}{
+		{FamilyCombinations, 0, 0, nil},
+		{FamilyCombinations, 3, 3, nil},
+		{FamilyCombinations, 2, 3, ErrKExceedsN},
+		{FamilyCombinations, -1, 0, ErrNegative},
+		{FamilyCombinations, 3, -1, ErrNegative},
+		{FamilyCombinations, 66, 33, nil},
+		{FamilyCombinations, 68, 34, ErrCountOverflow},
+		{FamilyCombinations, math.MaxInt, 1, nil},
+		{FamilyCombinations, math.MaxInt, 2, ErrCountOverflow},
+		{FamilyCombinations, math.MaxInt, math.MaxInt - 1, nil},
+		{FamilyDupCombinations, 0, 0, nil},
+		{FamilyDupCombinations, 0, 3, nil},
+		{FamilyDupCombinations, 2, 3, nil},
+		{FamilyDupCombinations, 3, -1, ErrNegative},
+		{FamilyDupCombinations, 34, 33, nil},
+		{FamilyDupCombinations, 35, 34, ErrCountOverflow},
+		{FamilyDupCombinations, math.MaxInt, 2, ErrCountOverflow},
+		{FamilyPermutations, 0, 0, nil},
+		{FamilyPermutations, 2, 3, ErrKExceedsN},
+		{FamilyPermutations, -3, -1, ErrNegative},
+		{FamilyPermutations, 20, 20, nil},
+		{FamilyPermutations, 21, 21, ErrCountOverflow},
+		{FamilyDupPermutations, 0, 0, nil},
+		{FamilyDupPermutations, 0, 3, nil},
+		{FamilyDupPermutations, 1, math.MaxInt, nil},
+		{FamilyDupPermutations, 2, 62, nil},
+		{FamilyDupPermutations, 2, 63, ErrCountOverflow},
+		{FamilyDupPermutations, -1, 3, ErrNegative},
+	}
+
+	for _, c := range cases {
+		err := c.family.Validate(c.n, c.k)
+		if !errors.Is(err, c.want) || (c.want == nil && err != nil) {
+			t.Errorf("%s n=%d k=%d: want: %v, got: %v", c.family, c.n, c.k, c.want, err)
+			continue
+		}
+		if err == nil {
+			continue
+		}
+
+		var sizeErr *SizeError
+		if !errors.As(err, &sizeErr) {
+			t.Errorf("%s n=%d k=%d: not a *SizeError: %T", c.family, c.n, c.k, err)
+			continue
+		}
+		if sizeErr.Family != c.family || sizeErr.N != c.n || sizeErr.K != c.k {
+			t.Errorf("%s n=%d k=%d: unexpected error: %+v", c.family, c.n, c.k, sizeErr)
+		}
+	}
+}
+
+func TestCheckedCount(t *testing.T) {
+	for _, family := range Families {
+		for n := 0; n <= 8; n++ {
+			for k := 0; k <= 8; k++ {
+				if k > n && (family == FamilyCombinations || family == FamilyPermutations) {
+					continue
+				}
+				got, err := family.CheckedCount(n, k)
+				if err != nil {
+					t.Fatalf("%s n=%d k=%d: %v", family, n, k, err)
+				}
+				if want := family.Count(n, k); got != want {
+					t.Errorf("%s n=%d k=%d: want: %d, got: %d", family, n, k, want, got)
+				}
+			}
+		}
+	}
+}
+
+func TestCountNearLimit(t *testing.T) {
+	cases := []struct {
+		family Family
+		n, k   int
+		want   int
+	}{
+		{FamilyCombinations, 62, 31, 465428353255261088},
+		{FamilyCombinations, 66, 33, 7219428434016265740},
+		{FamilyCombinations, 67, 29, 7886597962249166160},
+		{FamilyCombinations, 100, 15, 253338471349988640},
+		{FamilyDupCombinations, 34, 33, 7219428434016265740},
+	}
+	for _, c := range cases {
+		checked, err := c.family.CheckedCount(c.n, c.k)
+		if err != nil {
+			t.Fatalf("%s n=%d k=%d: %v", c.family, c.n, c.k, err)
+		}
+		if got := c.family.Count(c.n, c.k); got != c.want || checked != c.want {
+			t.Errorf("%s n=%d k=%d: want: %d, got: %d and checked %d",
+				c.family, c.n, c.k, c.want, got, checked)
+		}
+	}
+
+	// the last combination of 62C31
+	n, k := 62, 31
+	last := make([]int, k)
+	for i := range last {
+		last[i] = n - k + i
+	}
+	count := CombinationCount(n, k)
+	if got := CombinationRank(n, last); got != count-1 {
+		t.Errorf("rank of the last: want: %d, got: %d", count-1, got)
+	}
+	got := make([]int, k)
+	CombinationUnrank(n, count-1, got)
+	if !reflect.DeepEqual(got, last) {
+		t.Errorf("unrank of the last: want: %v, got: %v", last, got)
+	}
+}
+
+func TestValidatedEnumeration(t *testing.T) {
+	targets := []struct {
+		family    Family
+		enumerate func(n, k int, f func([]int)) error
+	}{
+		{FamilyCombinations, Combinations},
+		{FamilyDupCombinations, DupCombinations},
+		{FamilyPermutations, Permutations},
+		{FamilyDupPermutations, DupPermutations},
+	}
+
+	for _, target := range targets {
+		t.Run(target.family.String(), func(t *testing.T) {
+			got := [][]int{}
+			err := target.enumerate(4, 3, func(pattern []int) {
+				got = append(got, append([]int{}, pattern...))
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := Implementations(target.family)[0].Collect(4, 3)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("want: %v, got: %v", want, got)
+			}
+
+			called := false
+			err = target.enumerate(3, -1, func([]int) { called = true })
+			if !errors.Is(err, ErrNegative) || called {
+				t.Errorf("negative: want: %v without calls, got: %v, called: %v",
+					ErrNegative, err, called)
+			}
+		})
+	}
+}
+
+// TestEdgeSizes checks the semantics of the edge sizes described in
+// the package document for every implementation.
+func TestEdgeSizes(t *testing.T) {
+	cases := []struct {
+		family Family
+		n, k   int
+		want   [][]int
+	}{
+		{FamilyCombinations, 0, 0, [][]int{{}}},
+		{FamilyCombinations, 3, 0, [][]int{{}}},
+		{FamilyCombinations, 0, 2, [][]int{}},
+		{FamilyCombinations, 2, 3, [][]int{}},
+		{FamilyDupCombinations, 0, 0, [][]int{{}}},
+		{FamilyDupCombinations, 0, 2, [][]int{}},
+		{FamilyDupCombinations, 1, 3, [][]int{{0, 0, 0}}},
+		{FamilyPermutations, 0, 0, [][]int{{}}},
+		{FamilyPermutations, 3, 0, [][]int{{}}},
+		{FamilyPermutations, 0, 2, [][]int{}},
+		{FamilyPermutations, 2, 3, [][]int{}},
+		{FamilyDupPermutations, 0, 0, [][]int{{}}},
+		{FamilyDupPermutations, 0, 2, [][]int{}},
+		{FamilyDupPermutations, 1, 3, [][]int{{0, 0, 0}}},
+	}
+
+	for _, c := range cases {
+		for _, impl := range Implementations(c.family) {
+			t.Run(fmt.Sprintf("%s/n=%d,k=%d", impl.FullName(), c.n, c.k), func(t *testing.T) {
+				got := collectPatterns(t, impl, c.n, c.k)
+				if len(got) != len(c.want) || (len(got) > 0 && !reflect.DeepEqual(got, c.want)) {
+					t.Errorf("want: %v, got: %v", c.want, got)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package combinatorics
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// workloadSink keeps results of workloads so that the compiler cannot drop
+// the computation.
+var workloadSink []int
+
+// workload is a realistic consumer of patterns.
+type workload struct {
+	name         string
+	family       Family
+	n, k         int // for benchmarks
+	testN, testK int // for tests
+	prepare      func(n, k int) workloadRun
+}
+
+type workloadRun struct {
+	reset   func()
+	consume func([]int)
+	result  func() []int
+}
+
+var workloads = []workload{
+	{"TSP", FamilyPermutations, 10, 10, 5, 5, prepareTSP},
+	{"Assignment", FamilyPermutations, 10, 10, 5, 5, prepareAssignment},
+	{"SubsetSum", FamilyCombinations, 24, 12, 8, 4, prepareSubsetSum},
+	{"CoinSum", FamilyDupCombinations, 18, 9, 5, 4, prepareCoinSum},
+	{"DiceSum", FamilyDupPermutations, 6, 8, 3, 4, prepareDiceSum},
+}
+
+// prepareTSP solves the travelling salesman problem of `n+1` cities by brute
+// force. The tour starts from the city 0 and visits the others in the order of
+// the pattern.
+func prepareTSP(n, k int) workloadRun {
+	r := rand.New(rand.NewSource(1))
+	dist := make([][]int, n+1)
+	for i := range dist {
+		dist[i] = make([]int, n+1)
+	}
+	for i := range dist {
+		for j := i + 1; j < len(dist); j++ {
+			d := r.Intn(100) + 1
+			dist[i][j] = d
+			dist[j][i] = d
+		}
+	}
+
+	best := math.MaxInt64
+	return workloadRun{
+		reset: func() {
+			best = math.MaxInt64
+		},
+		consume: func(pattern []int) {
+			length := 0
+			city := 0
+			for _, next := range pattern {
+				length += dist[city][next+1]
+				city = next + 1
+			}
+			length += dist[city][0]
+			if length < best {
+				best = length
+			}
+		},
+		result: func() []int {
+			return []int{best}
+		},
+	}
+}
+
+// prepareAssignment minimizes the cost to assign `n` workers to `n` jobs.
+// The pattern assigns the i-th worker to the job of `pattern[i]`.
+func prepareAssignment(n, k int) workloadRun {
+	r := rand.New(rand.NewSource(2))
+	cost := make([][]int, n)
+	for i := range cost {
+		cost[i] = make([]int, n)
+		for j := range cost[i] {
+			cost[i][j] = r.Intn(1000)
+		}
+	}
+
+	best := math.MaxInt64
+	bestRank := -1
+	rank := 0
+	return workloadRun{
+		reset: func() {
+			best = math.MaxInt64
+			bestRank = -1
+			rank = 0
+		},
+		consume: func(pattern []int) {
+			total := 0
+			for worker, job := range pattern {
+				total += cost[worker][job]
+			}
+			if total < best {
+				best = total
+				bestRank = rank
+			}
+			rank++
+		},
+		result: func() []int {
+			return []int{best, bestRank}
+		},
+	}
+}
+
+// prepareSubsetSum counts subsets of `k` weights from `n` weights whose sum
+// is the half of the total.
+func prepareSubsetSum(n, k int) workloadRun {
+	r := rand.New(rand.NewSource(3))
+	weights := make([]int, n)
+	target := 0
+	for i := range weights {
+		weights[i] = r.Intn(100)
+		target += weights[i]
+	}
+	target /= 2
+
+	count := 0
+	return workloadRun{
+		reset: func() {
+			count = 0
+		},
+		consume: func(pattern []int) {
+			sum := 0
+			for _, i := range pattern {
+				sum += weights[i]
+			}
+			if sum == target {
+				count++
+			}
+		},
+		result: func() []int {
+			return []int{count}
+		},
+	}
+}
+
+// prepareCoinSum makes the histogram of the amounts paid by `k` coins of `n`
+// denominations.
+func prepareCoinSum(n, k int) workloadRun {
+	denominations := make([]int, n)
+	for i := range denominations {
+		denominations[i] = i*i + 1
+	}
+	histogram := make([]int, k*denominations[len(denominations)-1]+1)
+
+	return workloadRun{
+		reset: func() {
+			for i := range histogram {
+				histogram[i] = 0
+			}
+		},
+		consume: func(pattern []int) {
+			amount := 0
+			for _, i := range pattern {
+				amount += denominations[i]
+			}
+			histogram[amount]++
+		},
+		result: func() []int {
+			return histogram
+		},
+	}
+}
+
+// prepareDiceSum makes the histogram of the sums of `k` dice with `n` faces.
+func prepareDiceSum(n, k int) workloadRun {
+	histogram := make([]int, k*n+1)
+
+	return workloadRun{
+		reset: func() {
+			for i := range histogram {
+				histogram[i] = 0
+			}
+		},
+		consume: func(pattern []int) {
+			sum := k // faces are from 1 to n
+			for _, face := range pattern {
+				sum += face
+			}
+			histogram[sum]++
+		},
+		result: func() []int {
+			return histogram
+		},
+	}
+}
+
+func TestWorkloads(t *testing.T) {
+	for _, w := range workloads {
+		t.Run(w.name, func(t *testing.T) {
+			run := w.prepare(w.testN, w.testK)
+
+			var want []int
+			for _, impl := range Implementations(w.family) {
+				run.reset()
+				impl.Each(w.testN, w.testK, run.consume)
+				got := append([]int{}, run.result()...)
+
+				if want == nil {
+					want = got
+					continue
+				}
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("%s: want: %v, got: %v", impl.Name, want, got)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkWorkloads(b *testing.B) {
+	for _, w := range workloads {
+		b.Run(w.name, func(b *testing.B) {
+			run := w.prepare(w.n, w.k)
+			count := w.family.Count(w.n, w.k)
+
+			for _, impl := range Implementations(w.family) {
+				name := fmt.Sprintf("%v/%s/n=%d,k=%d", w.family, impl.Name, w.n, w.k)
+				b.Run(name, func(b *testing.B) {
+					benchmarkPerPattern(b, count, func() {
+						run.reset()
+						impl.Each(w.n, w.k, run.consume)
+						workloadSink = run.result()
+					})
+				})
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package benchresult
+
+import (
+	"math"
+	"sort"
+)
+
+// Comparison compares the runs of a benchmark before and after a change.
+type Comparison struct {
+	Name          string
+	Before, After Summary
+	// Delta is the relative change of the mean, e.g. 0.1 for 10% larger.
+	Delta float64
+	// P is the two-sided p-value of the Mann-Whitney U test.
+	P float64
+}
+
+// Compare compares the benchmarks which exist in both results for the unit.
+// The comparisons are in the order of `before`.
+func Compare(before, after []Result, unit string) []Comparison {
+	afterByName := map[string]Summary{}
+	for _, summary := range Summarize(after, unit) {
+		afterByName[summary.Name] = summary
+	}
+
+	comparisons := []Comparison{}
+	for _, b := range Summarize(before, unit) {
+		a, ok := afterByName[b.Name]
+		if !ok {
+			continue
+		}
+		comparisons = append(comparisons, Comparison{
+			Name:   b.Name,
+			Before: b,
+			After:  a,
+			Delta:  a.Mean/b.Mean - 1,
+			P:      MannWhitneyU(b.Samples, a.Samples),
+		})
+	}
+	return comparisons
+}
+
+// exactMannWhitneyLimit is the largest product of the sample sizes for
+// which the exact distribution of U is used.
+const exactMannWhitneyLimit = 400
+
+// MannWhitneyU returns the two-sided p-value of the Mann-Whitney U test,
+// which tells whether the samples come from the same distribution. It uses
+// the exact distribution for small samples without ties, or the normal
+// approximation with the correction for ties.
+func MannWhitneyU(xs, ys []float64) float64 {
+	n1, n2 := len(xs), len(ys)
+	if n1 == 0 || n2 == 0 {
+		return 1
+	}
+
+	u, tieTerm := mannWhitneyStatistic(xs, ys)
+	if tieTerm == 0 && n1*n2 <= exactMannWhitneyLimit {
+		return exactMannWhitneyP(n1, n2, u)
+	}
+	return normalMannWhitneyP(n1, n2, u, tieTerm)
+}
+
+// mannWhitneyStatistic computes U of `xs` by mid-ranks, and the sum of
+// t^3-t over the groups of t ties.
+func mannWhitneyStatistic(xs, ys []float64) (u, tieTerm float64) {
+	type sample struct {
+		value float64
+		inX   bool
+	}
+	samples := make([]sample, 0, len(xs)+len(ys))
+	for _, x := range xs {
+		samples = append(samples, sample{x, true})
+	}
+	for _, y := range ys {
+		samples = append(samples, sample{y, false})
+	}
+	sort.Slice(samples, func(i, j int) bool {
+		return samples[i].value < samples[j].value
+	})
+
+	rankSum := 0.0
+	for i := 0; i < len(samples); {
+		j := i
+		for j < len(samples) && samples[j].value == samples[i].value {
+			j++
+		}
+		// ranks from i+1 to j share their mean
+		rank := float64(i+1+j) / 2
+		for _, s := range samples[i:j] {
+			if s.inX {
+				rankSum += rank
+			}
+		}
+		t := float64(j - i)
+		tieTerm += t*t*t - t
+		i = j
+	}
+
+	n1 := float64(len(xs))
+	return rankSum - n1*(n1+1)/2, tieTerm
+}
+
+func exactMannWhitneyP(n1, n2 int, u float64) float64 {
+	// counts[i][j][v] is the number of the arrangements of i xs and j ys
+	// whose U is v. An arrangement ends with an x, which is larger than
+	// j ys, or with a y.
+	maxU := n1 * n2
+	counts := make([][][]float64, n1+1)
+	for i := range counts {
+		counts[i] = make([][]float64, n2+1)
+		for j := range counts[i] {
+			counts[i][j] = make([]float64, maxU+1)
+			if i == 0 || j == 0 {
+				counts[i][j][0] = 1
+				continue
+			}
+			for v := 0; v <= i*j; v++ {
+				c := counts[i][j-1][v]
+				if v >= j {
+					c += counts[i-1][j][v-j]
+				}
+				counts[i][j][v] = c
+			}
+		}
+	}
+
+	total, below, above := 0.0, 0.0, 0.0
+	for v, c := range counts[n1][n2] {
+		total += c
+		if float64(v) <= u {
+			below += c
+		}
+		if float64(v) >= u {
+			above += c
+		}
+	}
+	return math.Min(1, 2*math.Min(below, above)/total)
+}
+
+func normalMannWhitneyP(n1, n2 int, u, tieTerm float64) float64 {
+	a, b := float64(n1), float64(n2)
+	n := a + b
+	mean := a * b / 2
+	variance := a * b / 12 * ((n + 1) - tieTerm/(n*(n-1)))
+	if variance <= 0 {
+		return 1
+	}
+
+	// with the continuity correction
+	z := (math.Abs(u-mean) - 0.5) / math.Sqrt(variance)
+	if z < 0 {
+		z = 0
+	}
+	return math.Min(1, math.Erfc(z/math.Sqrt2))
+}
@@ -0,0 +1,72 @@
+package benchresult
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestMannWhitneyU(t *testing.T) {
+	cases := []struct {
+		name   string
+		xs, ys []float64
+		want   float64
+	}{
+		// all xs are smaller: 2 of C(10, 5) arrangements are as extreme
+		{"separated", []float64{1, 2, 3, 4, 5}, []float64{6, 7, 8, 9, 10}, 2.0 / 252},
+		{"reversed", []float64{6, 7, 8, 9, 10}, []float64{1, 2, 3, 4, 5}, 2.0 / 252},
+		// U = 1 with 3 and 3: 2 arrangements have U <= 1 of C(6, 3)
+		{"nearly separated", []float64{1, 2, 4}, []float64{3, 5, 6}, 2 * 2.0 / 20},
+		{"interleaved", []float64{1, 4, 5, 8}, []float64{2, 3, 6, 7}, 1},
+		{"empty", nil, []float64{1}, 1},
+		{"identical", []float64{1, 1, 1}, []float64{1, 1, 1}, 1},
+	}
+	for _, c := range cases {
+		got := MannWhitneyU(c.xs, c.ys)
+		if math.Abs(got-c.want) > 1e-12 {
+			t.Errorf("%s: want: %v, got: %v", c.name, c.want, got)
+		}
+	}
+}
+
+func TestMannWhitneyUNormal(t *testing.T) {
+	// ties make it use the normal approximation
+	xs := []float64{1, 1, 2, 2, 3, 3, 4, 4}
+	ys := []float64{5, 5, 6, 6, 7, 7, 8, 8}
+	if p := MannWhitneyU(xs, ys); p > 0.01 {
+		t.Errorf("separated: want a small p-value, got: %v", p)
+	}
+	if p := MannWhitneyU(xs, xs); p < 0.9 {
+		t.Errorf("same: want a large p-value, got: %v", p)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	before, err := Parse(strings.NewReader(`
+BenchmarkX/A-4 10 100 ns/op
+BenchmarkX/A-4 10 102 ns/op
+BenchmarkX/A-4 10 98 ns/op
+BenchmarkX/Removed-4 10 100 ns/op
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	after, err := Parse(strings.NewReader(`
+BenchmarkX/Added-4 10 100 ns/op
+BenchmarkX/A-4 10 121 ns/op
+BenchmarkX/A-4 10 119 ns/op
+BenchmarkX/A-4 10 120 ns/op
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	comparisons := Compare(before.Results, after.Results, "ns/op")
+	if len(comparisons) != 1 {
+		t.Fatalf("want 1 comparison, got: %+v", comparisons)
+	}
+	c := comparisons[0]
+	if c.Name != "X/A" || math.Abs(c.Delta-0.2) > 1e-12 || math.Abs(c.P-0.1) > 1e-12 {
+		t.Errorf("unexpected comparison: %+v", c)
+	}
+}
@@ -0,0 +1,101 @@
+package benchresult
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// Export is the records with the environment.
+type Export struct {
+	Environment Environment `json:"environment"`
+	Records     []Record    `json:"records"`
+}
+
+// WriteJSON writes the export as an indented JSON object.
+func WriteJSON(w io.Writer, export Export) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(export)
+}
+
+// WriteCSV writes a row for each record with the environment. The custom
+// metrics of all records are columns in the order of the units, and they
+// are empty for records without them.
+func WriteCSV(w io.Writer, export Export) error {
+	units := customUnits(export.Records)
+
+	header := []string{
+		"goos", "goarch", "cpu", "go_version", "commit",
+		"benchmark", "group", "family", "variant", "n", "k", "pkg", "procs", "iterations",
+		unitNsPerOp, unitBytesPerOp, unitAllocsPerOp,
+	}
+	header = append(header, units...)
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	env := export.Environment
+	for _, record := range export.Records {
+		row := []string{
+			env.GOOS, env.GOARCH, env.CPU, env.GoVersion, env.Commit,
+			record.Benchmark, record.Group, record.Family, record.Variant,
+			formatOptionalInt(record.N), formatOptionalInt(record.K),
+			record.Pkg, strconv.Itoa(record.Procs), strconv.Itoa(record.Iterations),
+			formatFloat(record.NsPerOp),
+			formatOptionalFloat(record.BytesPerOp),
+			formatOptionalFloat(record.AllocsPerOp),
+		}
+		for _, unit := range units {
+			value, ok := record.Metrics[unit]
+			if ok {
+				row = append(row, formatFloat(value))
+			} else {
+				row = append(row, "")
+			}
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func customUnits(records []Record) []string {
+	found := map[string]bool{}
+	units := []string{}
+	for _, record := range records {
+		for unit := range record.Metrics {
+			if !found[unit] {
+				found[unit] = true
+				units = append(units, unit)
+			}
+		}
+	}
+	sort.Strings(units)
+	return units
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func formatOptionalFloat(value *float64) string {
+	if value == nil {
+		return ""
+	}
+	return formatFloat(*value)
+}
+
+func formatOptionalInt(value *int) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.Itoa(*value)
+}
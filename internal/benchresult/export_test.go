@@ -0,0 +1,52 @@
+package benchresult
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func sampleExport(t *testing.T) Export {
+	set, err := Parse(strings.NewReader(sampleOutput))
+	if err != nil {
+		t.Fatal(err)
+	}
+	env := EnvironmentOf(set.Config)
+	env.GoVersion = "go1.22.0"
+	env.Commit = "abc123"
+	return Export{Environment: env, Records: Records(set.Results)}
+}
+
+func TestWriteJSON(t *testing.T) {
+	export := sampleExport(t)
+
+	var b strings.Builder
+	if err := WriteJSON(&b, export); err != nil {
+		t.Fatal(err)
+	}
+	var got Export
+	if err := json.Unmarshal([]byte(b.String()), &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, export) {
+		t.Errorf("not round-tripped: want: %+v, got: %+v", export, got)
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	const env = "linux,amd64,Some CPU @ 2.00GHz,go1.22.0,abc123,"
+	const pkg = "github.com/ikngtty/benchmark-go-combinatorics/combinatorics"
+	want := "goos,goarch,cpu,go_version,commit,benchmark,group,family,variant,n,k,pkg,procs,iterations,ns/op,B/op,allocs/op,ns/pattern\n" +
+		env + "\"Combinations/Recursive1/n=24,k=12\",Combinations,Combinations,Recursive1,24,12," + pkg + ",4,20,55564969,96,1,21.5\n" +
+		env + "\"Combinations/Recursive1/n=24,k=12\",Combinations,Combinations,Recursive1,24,12," + pkg + ",4,20,55564971,96,1,21.5\n" +
+		env + "Next/Combination,Next,,Combination,,," + pkg + ",4,30,36928434,,,\n"
+
+	var b strings.Builder
+	if err := WriteCSV(&b, sampleExport(t)); err != nil {
+		t.Fatal(err)
+	}
+	if got := b.String(); got != want {
+		t.Errorf("want:\n%s\ngot:\n%s", want, got)
+	}
+}
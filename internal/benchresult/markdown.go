@@ -0,0 +1,126 @@
+package benchresult
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// WriteMarkdown writes tables of the summaries grouped by SplitName, which
+// is the family and the size for the benchmarks of this repository. Each
+// table has a column of the speed relative to the fastest variant in it,
+// and a column of the means of each of `columns`, e.g. the summaries of
+// B/op and allocs/op, matched by the names. Columns without summaries are
+// omitted.
+func WriteMarkdown(w io.Writer, summaries []Summary, columns ...[]Summary) error {
+	groups, byGroup := groupSummaries(summaries)
+
+	extras := []extraColumn{}
+	for _, column := range columns {
+		if len(column) == 0 {
+			continue
+		}
+		means := map[string]float64{}
+		for _, summary := range column {
+			means[summary.Name] = summary.Mean
+		}
+		extras = append(extras, extraColumn{column[0].Unit, means})
+	}
+
+	for i, group := range groups {
+		if i > 0 {
+			if _, err := fmt.Fprintln(w); err != nil {
+				return err
+			}
+		}
+		if err := writeTable(w, group, byGroup[group], extras); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func groupSummaries(summaries []Summary) ([]string, map[string][]Summary) {
+	groups := []string{}
+	byGroup := map[string][]Summary{}
+	for _, summary := range summaries {
+		group, _ := SplitName(summary.Name)
+		if _, ok := byGroup[group]; !ok {
+			groups = append(groups, group)
+		}
+		byGroup[group] = append(byGroup[group], summary)
+	}
+	return groups, byGroup
+}
+
+// extraColumn is a column of the means of another unit by the names.
+type extraColumn struct {
+	unit  string
+	means map[string]float64
+}
+
+func writeTable(w io.Writer, group string, summaries []Summary, extras []extraColumn) error {
+	fastest := math.Inf(1)
+	for _, summary := range summaries {
+		if summary.Mean < fastest {
+			fastest = summary.Mean
+		}
+	}
+	unit := summaries[0].Unit
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "#### %s\n\n", group)
+	fmt.Fprintf(&b, "| Variant | %s | ±95%% | Runs | Relative |", unit)
+	for _, extra := range extras {
+		fmt.Fprintf(&b, " %s |", extra.unit)
+	}
+	b.WriteString("\n| --- | ---: | ---: | ---: | ---: |")
+	b.WriteString(strings.Repeat(" ---: |", len(extras)))
+	b.WriteString("\n")
+	for _, summary := range summaries {
+		_, variant := SplitName(summary.Name)
+		if variant == "" {
+			variant = summary.Name
+		}
+		margin := "-"
+		if !math.IsNaN(summary.Margin) {
+			margin = FormatValue(summary.Margin)
+		}
+		relative := "-"
+		if fastest > 0 {
+			relative = fmt.Sprintf("×%.2f", summary.Mean/fastest)
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %d | %s |",
+			variant, FormatValue(summary.Mean), margin, len(summary.Samples),
+			relative)
+		for _, extra := range extras {
+			value := "-"
+			if mean, ok := extra.means[summary.Name]; ok {
+				value = FormatValue(mean)
+			}
+			fmt.Fprintf(&b, " %s |", value)
+		}
+		b.WriteString("\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// FormatValue formats a value of a metric with about 3 significant digits,
+// without an exponent for large values.
+func FormatValue(value float64) string {
+	abs := math.Abs(value)
+	switch {
+	case abs >= 100:
+		return strconv.FormatFloat(value, 'f', 0, 64)
+	case abs >= 10:
+		return strconv.FormatFloat(value, 'f', 1, 64)
+	case abs >= 1:
+		return strconv.FormatFloat(value, 'f', 2, 64)
+	default:
+		return strconv.FormatFloat(value, 'g', 3, 64)
+	}
+}
@@ -0,0 +1,86 @@
+package benchresult
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestWriteMarkdown(t *testing.T) {
+	summaries := []Summary{
+		{Name: "Combinations/Recursive0/n=4,k=2", Unit: "ns/op",
+			Samples: []float64{300, 300}, Mean: 300, Margin: 0},
+		{Name: "Combinations/WithCarrying0/n=4,k=2", Unit: "ns/op",
+			Samples: []float64{100}, Mean: 100, Margin: math.NaN()},
+		{Name: "DupCombinations/Recursive0/n=4,k=2", Unit: "ns/op",
+			Samples: []float64{12.5}, Mean: 12.5, Margin: math.NaN()},
+	}
+	want := `#### Combinations/n=4,k=2
+
+| Variant | ns/op | ±95% | Runs | Relative |
+| --- | ---: | ---: | ---: | ---: |
+| Recursive0 | 300 | 0 | 2 | ×3.00 |
+| WithCarrying0 | 100 | - | 1 | ×1.00 |
+
+#### DupCombinations/n=4,k=2
+
+| Variant | ns/op | ±95% | Runs | Relative |
+| --- | ---: | ---: | ---: | ---: |
+| Recursive0 | 12.5 | - | 1 | ×1.00 |
+`
+
+	var b strings.Builder
+	if err := WriteMarkdown(&b, summaries); err != nil {
+		t.Fatal(err)
+	}
+	if got := b.String(); got != want {
+		t.Errorf("want:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func TestWriteMarkdownColumns(t *testing.T) {
+	summaries := []Summary{
+		{Name: "Combinations/Recursive0/n=4,k=2", Unit: "ns/op",
+			Samples: []float64{300}, Mean: 300, Margin: math.NaN()},
+		{Name: "Combinations/WithCarrying0/n=4,k=2", Unit: "ns/op",
+			Samples: []float64{100}, Mean: 100, Margin: math.NaN()},
+	}
+	bytes := []Summary{
+		{Name: "Combinations/Recursive0/n=4,k=2", Unit: "B/op", Mean: 1536},
+		{Name: "Combinations/WithCarrying0/n=4,k=2", Unit: "B/op", Mean: 16},
+	}
+	allocs := []Summary{
+		{Name: "Combinations/Recursive0/n=4,k=2", Unit: "allocs/op", Mean: 40},
+	}
+	want := `#### Combinations/n=4,k=2
+
+| Variant | ns/op | ±95% | Runs | Relative | B/op | allocs/op |
+| --- | ---: | ---: | ---: | ---: | ---: | ---: |
+| Recursive0 | 300 | - | 1 | ×3.00 | 1536 | 40.0 |
+| WithCarrying0 | 100 | - | 1 | ×1.00 | 16.0 | - |
+`
+
+	var b strings.Builder
+	if err := WriteMarkdown(&b, summaries, bytes, allocs, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := b.String(); got != want {
+		t.Errorf("want:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func TestFormatValue(t *testing.T) {
+	cases := map[float64]string{
+		55564969: "55564969",
+		123.4:    "123",
+		21.54:    "21.5",
+		1.234:    "1.23",
+		0.01234:  "0.0123",
+		0:        "0",
+	}
+	for value, want := range cases {
+		if got := FormatValue(value); got != want {
+			t.Errorf("%v: want: %q, got: %q", value, want, got)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+// Package benchresult parses and summarizes the output of `go test -bench`.
+package benchresult
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Result is a line of the output of `go test -bench`.
+type Result struct {
+	// Name is the name of the benchmark without the prefix "Benchmark" and
+	// the suffix of GOMAXPROCS, e.g. "Combinations/Recursive0/n=24,k=12".
+	Name       string
+	Pkg        string
+	Procs      int
+	Iterations int
+	Metrics    map[string]float64 // by the unit, e.g. "ns/op"
+}
+
+// Set is the parsed output of `go test -bench`.
+type Set struct {
+	// Config holds the key-value lines such as "goos: linux" and
+	// "cpu: ...". A key of "pkg" is stored in each result instead.
+	Config  map[string]string
+	Results []Result
+}
+
+// Parse parses the output of `go test -bench`. Lines which are neither
+// results nor configurations, such as "PASS", are ignored.
+func Parse(r io.Reader) (*Set, error) {
+	set := Set{Config: map[string]string{}}
+	pkg := ""
+
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := scanner.Text()
+
+		if strings.HasPrefix(line, "Benchmark") {
+			result, ok, err := parseResult(line)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", lineNo, err)
+			}
+			if ok {
+				result.Pkg = pkg
+				set.Results = append(set.Results, result)
+			}
+			continue
+		}
+
+		key, value, ok := parseConfig(line)
+		if !ok {
+			continue
+		}
+		if key == "pkg" {
+			pkg = value
+		} else {
+			set.Config[key] = value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return &set, nil
+}
+
+// parseResult parses a line like
+// "BenchmarkX/Y-4  100  123 ns/op  4 allocs/op". It returns false for
+// a line which only has the name, which is printed before logs.
+func parseResult(line string) (Result, bool, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 2 {
+		return Result{}, false, nil
+	}
+	if len(fields)%2 != 0 {
+		return Result{}, false, fmt.Errorf("invalid result: %q", line)
+	}
+
+	name, procs := splitProcs(strings.TrimPrefix(fields[0], "Benchmark"))
+	iterations, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return Result{}, false, fmt.Errorf("invalid iterations: %q", line)
+	}
+
+	result := Result{
+		Name:       name,
+		Procs:      procs,
+		Iterations: iterations,
+		Metrics:    map[string]float64{},
+	}
+	for i := 2; i < len(fields); i += 2 {
+		value, err := strconv.ParseFloat(fields[i], 64)
+		if err != nil {
+			return Result{}, false, fmt.Errorf("invalid value %q: %q", fields[i], line)
+		}
+		result.Metrics[fields[i+1]] = value
+	}
+	return result, true, nil
+}
+
+// splitProcs splits the suffix of GOMAXPROCS like "-4" from the name. It is
+// 1 if there is no suffix.
+func splitProcs(name string) (string, int) {
+	i := strings.LastIndex(name, "-")
+	if i < 0 {
+		return name, 1
+	}
+	procs, err := strconv.Atoi(name[i+1:])
+	if err != nil || procs <= 0 {
+		return name, 1
+	}
+	return name[:i], procs
+}
+
+func parseConfig(line string) (key, value string, ok bool) {
+	i := strings.Index(line, ":")
+	if i <= 0 {
+		return "", "", false
+	}
+	key = line[:i]
+	if strings.ContainsAny(key, " \t") {
+		return "", "", false
+	}
+	return key, strings.TrimSpace(line[i+1:]), true
+}
@@ -0,0 +1,80 @@
+package benchresult
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const sampleOutput = `goos: linux
+goarch: amd64
+pkg: github.com/ikngtty/benchmark-go-combinatorics/combinatorics
+cpu: Some CPU @ 2.00GHz
+BenchmarkCombinations/Recursive1/n=24,k=12-4         	      20	  55564969 ns/op	        21.5 ns/pattern	      96 B/op	       1 allocs/op
+BenchmarkCombinations/Recursive1/n=24,k=12-4         	      20	  55564971 ns/op	        21.5 ns/pattern	      96 B/op	       1 allocs/op
+BenchmarkNext
+BenchmarkNext/Combination
+    bench_test.go:12: some log
+BenchmarkNext/Combination-4 	      30	  36928434 ns/op
+PASS
+ok  	github.com/ikngtty/benchmark-go-combinatorics/combinatorics	3.456s
+`
+
+func TestParse(t *testing.T) {
+	set, err := Parse(strings.NewReader(sampleOutput))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantConfig := map[string]string{
+		"goos":   "linux",
+		"goarch": "amd64",
+		"cpu":    "Some CPU @ 2.00GHz",
+	}
+	if !reflect.DeepEqual(set.Config, wantConfig) {
+		t.Errorf("config: want: %v, got: %v", wantConfig, set.Config)
+	}
+
+	const pkg = "github.com/ikngtty/benchmark-go-combinatorics/combinatorics"
+	wantResults := []Result{
+		{"Combinations/Recursive1/n=24,k=12", pkg, 4, 20, map[string]float64{
+			"ns/op": 55564969, "ns/pattern": 21.5, "B/op": 96, "allocs/op": 1}},
+		{"Combinations/Recursive1/n=24,k=12", pkg, 4, 20, map[string]float64{
+			"ns/op": 55564971, "ns/pattern": 21.5, "B/op": 96, "allocs/op": 1}},
+		{"Next/Combination", pkg, 4, 30, map[string]float64{
+			"ns/op": 36928434}},
+	}
+	if !reflect.DeepEqual(set.Results, wantResults) {
+		t.Errorf("results: want: %v, got: %v", wantResults, set.Results)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, output := range []string{
+		"BenchmarkX-4 x 1 ns/op",
+		"BenchmarkX-4 1 x ns/op",
+		"BenchmarkX-4 1 1",
+	} {
+		if _, err := Parse(strings.NewReader(output)); err == nil {
+			t.Errorf("%q: want an error", output)
+		}
+	}
+}
+
+func TestSplitProcs(t *testing.T) {
+	cases := []struct {
+		in    string
+		name  string
+		procs int
+	}{
+		{"X/Y-4", "X/Y", 4},
+		{"X/Y", "X/Y", 1},
+		{"X/n=3,k=-1-8", "X/n=3,k=-1", 8},
+	}
+	for _, c := range cases {
+		name, procs := splitProcs(c.in)
+		if name != c.name || procs != c.procs {
+			t.Errorf("%q: want: %q %d, got: %q %d", c.in, c.name, c.procs, name, procs)
+		}
+	}
+}
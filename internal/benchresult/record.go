@@ -0,0 +1,134 @@
+package benchresult
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Environment is where the benchmarks ran.
+type Environment struct {
+	GOOS      string `json:"goos"`
+	GOARCH    string `json:"goarch"`
+	CPU       string `json:"cpu"`
+	GoVersion string `json:"goVersion"`
+	Commit    string `json:"commit"`
+}
+
+// EnvironmentOf takes the environment from the configuration lines of
+// the output. `go test` prints "goos", "goarch" and "cpu", and the others
+// are taken from the lines like "go: go1.22.0" and "commit: abc123" if they
+// are added.
+func EnvironmentOf(config map[string]string) Environment {
+	return Environment{
+		GOOS:      config["goos"],
+		GOARCH:    config["goarch"],
+		CPU:       config["cpu"],
+		GoVersion: config["go"],
+		Commit:    config["commit"],
+	}
+}
+
+// Record is a structured result of a benchmark.
+type Record struct {
+	// Benchmark is the name of the result, e.g.
+	// "Combinations/Recursive0/n=24,k=12".
+	Benchmark string `json:"benchmark"`
+	// Group is the first element of the name, which is the benchmark
+	// function, e.g. "Combinations", "Flat" or "Workloads".
+	Group string `json:"group"`
+	// Family is the element of the name which is a family, e.g.
+	// "Combinations" of "Workloads/TSP/Permutations/WithCarrying0/n=10,k=10".
+	// It is empty if there is no such element.
+	Family  string `json:"family"`
+	Variant string `json:"variant"`
+	// N and K are nil if the name has no size.
+	N          *int   `json:"n,omitempty"`
+	K          *int   `json:"k,omitempty"`
+	Pkg        string `json:"pkg"`
+	Procs      int    `json:"procs"`
+	Iterations int    `json:"iterations"`
+
+	NsPerOp float64 `json:"nsPerOp"`
+	// BytesPerOp and AllocsPerOp are nil without -benchmem.
+	BytesPerOp  *float64 `json:"bytesPerOp,omitempty"`
+	AllocsPerOp *float64 `json:"allocsPerOp,omitempty"`
+	// Metrics are the custom metrics by the unit, e.g. "ns/pattern".
+	Metrics map[string]float64 `json:"metrics,omitempty"`
+}
+
+const (
+	unitNsPerOp     = "ns/op"
+	unitBytesPerOp  = "B/op"
+	unitAllocsPerOp = "allocs/op"
+)
+
+// families are the names of the families of this repository.
+var families = map[string]bool{
+	"Combinations":    true,
+	"DupCombinations": true,
+	"Permutations":    true,
+	"DupPermutations": true,
+}
+
+// NewRecord structures the result.
+func NewRecord(result Result) Record {
+	elems := strings.Split(result.Name, "/")
+	family := ""
+	for _, elem := range elems {
+		if families[elem] {
+			family = elem
+			break
+		}
+	}
+	_, variant := SplitName(result.Name)
+
+	record := Record{
+		Benchmark:  result.Name,
+		Group:      elems[0],
+		Family:     family,
+		Variant:    variant,
+		Pkg:        result.Pkg,
+		Procs:      result.Procs,
+		Iterations: result.Iterations,
+		NsPerOp:    result.Metrics[unitNsPerOp],
+	}
+	if n, k, ok := sizeOf(result.Name); ok {
+		record.N, record.K = &n, &k
+	}
+
+	for unit, value := range result.Metrics {
+		value := value
+		switch unit {
+		case unitNsPerOp:
+		case unitBytesPerOp:
+			record.BytesPerOp = &value
+		case unitAllocsPerOp:
+			record.AllocsPerOp = &value
+		default:
+			if record.Metrics == nil {
+				record.Metrics = map[string]float64{}
+			}
+			record.Metrics[unit] = value
+		}
+	}
+	return record
+}
+
+// Records structures the results.
+func Records(results []Result) []Record {
+	records := make([]Record, len(results))
+	for i, result := range results {
+		records[i] = NewRecord(result)
+	}
+	return records
+}
+
+// sizeOf finds the size like "n=24,k=12" in the name.
+func sizeOf(name string) (n, k int, ok bool) {
+	for _, elem := range strings.Split(name, "/") {
+		if _, err := fmt.Sscanf(elem, "n=%d,k=%d", &n, &k); err == nil {
+			return n, k, true
+		}
+	}
+	return 0, 0, false
+}
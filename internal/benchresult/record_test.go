@@ -0,0 +1,79 @@
+package benchresult
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRecords(t *testing.T) {
+	set, err := Parse(strings.NewReader(sampleOutput))
+	if err != nil {
+		t.Fatal(err)
+	}
+	records := Records(set.Results)
+
+	n, k := 24, 12
+	bytes, allocs := 96.0, 1.0
+	const pkg = "github.com/ikngtty/benchmark-go-combinatorics/combinatorics"
+	want := Record{
+		Benchmark:   "Combinations/Recursive1/n=24,k=12",
+		Group:       "Combinations",
+		Family:      "Combinations",
+		Variant:     "Recursive1",
+		N:           &n,
+		K:           &k,
+		Pkg:         pkg,
+		Procs:       4,
+		Iterations:  20,
+		NsPerOp:     55564969,
+		BytesPerOp:  &bytes,
+		AllocsPerOp: &allocs,
+		Metrics:     map[string]float64{"ns/pattern": 21.5},
+	}
+	if !reflect.DeepEqual(records[0], want) {
+		t.Errorf("want: %+v, got: %+v", want, records[0])
+	}
+
+	want = Record{
+		Benchmark:  "Next/Combination",
+		Group:      "Next",
+		Variant:    "Combination",
+		Pkg:        pkg,
+		Procs:      4,
+		Iterations: 30,
+		NsPerOp:    36928434,
+	}
+	if !reflect.DeepEqual(records[2], want) {
+		t.Errorf("want: %+v, got: %+v", want, records[2])
+	}
+}
+
+func TestNewRecordFamily(t *testing.T) {
+	cases := []struct {
+		name, group, family, variant string
+	}{
+		{"Workloads/TSP/Permutations/WithCarrying0/n=10,k=10",
+			"Workloads", "Permutations", "WithCarrying0"},
+		{"Flat/DupCombinations/Flat/n=14,k=7", "Flat", "DupCombinations", "Flat"},
+		{"Next/DupPermutation", "Next", "", "DupPermutation"},
+	}
+	for _, c := range cases {
+		record := NewRecord(Result{Name: c.name})
+		if record.Group != c.group || record.Family != c.family || record.Variant != c.variant {
+			t.Errorf("%s: want: %s, %s, %s, got: %s, %s, %s", c.name,
+				c.group, c.family, c.variant, record.Group, record.Family, record.Variant)
+		}
+	}
+}
+
+func TestEnvironmentOf(t *testing.T) {
+	config := map[string]string{
+		"goos": "linux", "goarch": "amd64", "cpu": "Some CPU",
+		"go": "go1.22.0", "commit": "abc123",
+	}
+	want := Environment{"linux", "amd64", "Some CPU", "go1.22.0", "abc123"}
+	if got := EnvironmentOf(config); got != want {
+		t.Errorf("want: %+v, got: %+v", want, got)
+	}
+}
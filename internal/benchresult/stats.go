@@ -0,0 +1,100 @@
+package benchresult
+
+import (
+	"math"
+	"strings"
+)
+
+// Summary aggregates the runs of a benchmark for a metric.
+type Summary struct {
+	Name    string
+	Unit    string
+	Samples []float64
+	Mean    float64
+	// Margin is the half width of the 95% confidence interval of the mean.
+	// It is NaN for a single sample.
+	Margin float64
+}
+
+// Summarize aggregates the results of the same name, e.g. given by
+// `-count`, for the unit. The summaries are in the order of the first
+// appearance of the names. Results without the unit are skipped.
+func Summarize(results []Result, unit string) []Summary {
+	indexes := map[string]int{}
+	summaries := []Summary{}
+	for _, result := range results {
+		value, ok := result.Metrics[unit]
+		if !ok {
+			continue
+		}
+		i, ok := indexes[result.Name]
+		if !ok {
+			i = len(summaries)
+			indexes[result.Name] = i
+			summaries = append(summaries, Summary{Name: result.Name, Unit: unit})
+		}
+		summaries[i].Samples = append(summaries[i].Samples, value)
+	}
+
+	for i := range summaries {
+		summaries[i].Mean, summaries[i].Margin = MeanCI95(summaries[i].Samples)
+	}
+	return summaries
+}
+
+// MeanCI95 computes the mean and the half width of its 95% confidence
+// interval by Student's t-distribution.
+func MeanCI95(samples []float64) (mean, margin float64) {
+	n := len(samples)
+	if n == 0 {
+		return math.NaN(), math.NaN()
+	}
+
+	sum := 0.0
+	for _, x := range samples {
+		sum += x
+	}
+	mean = sum / float64(n)
+	if n == 1 {
+		return mean, math.NaN()
+	}
+
+	squares := 0.0
+	for _, x := range samples {
+		squares += (x - mean) * (x - mean)
+	}
+	stddev := math.Sqrt(squares / float64(n-1))
+	return mean, tQuantile975(n-1) * stddev / math.Sqrt(float64(n))
+}
+
+// tQuantiles975 are the 97.5th percentiles of Student's t-distribution for
+// the degrees of freedom from 1 to 30.
+var tQuantiles975 = []float64{
+	12.706, 4.303, 3.182, 2.776, 2.571, 2.447, 2.365, 2.306, 2.262, 2.228,
+	2.201, 2.179, 2.160, 2.145, 2.131, 2.120, 2.110, 2.101, 2.093, 2.086,
+	2.080, 2.074, 2.069, 2.064, 2.060, 2.056, 2.052, 2.048, 2.045, 2.042,
+}
+
+func tQuantile975(df int) float64 {
+	if df <= len(tQuantiles975) {
+		return tQuantiles975[df-1]
+	}
+	return 1.960
+}
+
+// SplitName splits the name of a benchmark into the group and the variant.
+// The variant is the last element which is not a size like "n=24,k=12",
+// and the group is the rest, e.g. "Combinations/Recursive0/n=24,k=12" is
+// split into "Combinations/n=24,k=12" and "Recursive0".
+func SplitName(name string) (group, variant string) {
+	elems := strings.Split(name, "/")
+	for i := len(elems) - 1; i > 0; i-- {
+		if strings.Contains(elems[i], "=") {
+			continue
+		}
+		variant = elems[i]
+		rest := append(append([]string{}, elems[:i]...), elems[i+1:]...)
+		return strings.Join(rest, "/"), variant
+	}
+	return name, ""
+}
@@ -0,0 +1,61 @@
+package benchresult
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestMeanCI95(t *testing.T) {
+	mean, margin := MeanCI95([]float64{1, 2, 3, 4, 5})
+	if mean != 3 {
+		t.Errorf("mean: want: 3, got: %v", mean)
+	}
+	// stddev = sqrt(2.5), t = 2.776 for df = 4
+	if want := 2.776 * math.Sqrt(2.5) / math.Sqrt(5); math.Abs(margin-want) > 1e-9 {
+		t.Errorf("margin: want: %v, got: %v", want, margin)
+	}
+
+	mean, margin = MeanCI95([]float64{7})
+	if mean != 7 || !math.IsNaN(margin) {
+		t.Errorf("single: want: 7 NaN, got: %v %v", mean, margin)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	set, err := Parse(strings.NewReader(sampleOutput))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	summaries := Summarize(set.Results, "ns/pattern")
+	if len(summaries) != 1 {
+		t.Fatalf("want 1 summary, got: %v", summaries)
+	}
+	s := summaries[0]
+	if s.Name != "Combinations/Recursive1/n=24,k=12" || len(s.Samples) != 2 ||
+		s.Mean != 21.5 || s.Margin != 0 {
+		t.Errorf("unexpected summary: %+v", s)
+	}
+
+	if got := len(Summarize(set.Results, "ns/op")); got != 2 {
+		t.Errorf("ns/op: want 2 summaries, got: %d", got)
+	}
+}
+
+func TestSplitName(t *testing.T) {
+	cases := []struct {
+		name, group, variant string
+	}{
+		{"Combinations/Recursive0/n=24,k=12", "Combinations/n=24,k=12", "Recursive0"},
+		{"Workloads/TSP/WithStack1/n=10,k=10", "Workloads/TSP/n=10,k=10", "WithStack1"},
+		{"Next/Combination", "Next", "Combination"},
+		{"Combinations", "Combinations", ""},
+	}
+	for _, c := range cases {
+		group, variant := SplitName(c.name)
+		if group != c.group || variant != c.variant {
+			t.Errorf("%q: want: %q %q, got: %q %q", c.name, c.group, c.variant, group, variant)
+		}
+	}
+}
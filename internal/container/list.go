@@ -0,0 +1,67 @@
+package container
+
+// List is a singly linked list, which concatenates another list without
+// copying.
+type List[T any] struct {
+	first *listNode[T]
+	last  *listNode[T]
+	len   int
+}
+
+type listNode[T any] struct {
+	child *listNode[T]
+	value T
+}
+
+// NewList creates an empty List.
+func NewList[T any]() *List[T] {
+	return &List[T]{}
+}
+
+// Len returns the number of the elements.
+func (list *List[T]) Len() int {
+	return list.len
+}
+
+// Add appends the element.
+func (list *List[T]) Add(elem T) {
+	node := &listNode[T]{nil, elem}
+	if list.first == nil {
+		list.first = node
+	} else {
+		list.last.child = node
+	}
+	list.last = node
+	list.len++
+}
+
+// Concat appends the elements of the other list by sharing its nodes. The
+// other list must not be added to afterwards.
+func (list *List[T]) Concat(other *List[T]) {
+	if other.first == nil {
+		return
+	}
+	if list.first == nil {
+		*list = *other
+		return
+	}
+	list.last.child = other.first
+	list.last = other.last
+	list.len += other.len
+}
+
+// Each calls `f` for each element from the first.
+func (list *List[T]) Each(f func(elem T)) {
+	for cur := list.first; cur != nil; cur = cur.child {
+		f(cur.value)
+	}
+}
+
+// ToSlice returns the elements as a new slice.
+func (list *List[T]) ToSlice() []T {
+	a := make([]T, 0, list.len)
+	list.Each(func(elem T) {
+		a = append(a, elem)
+	})
+	return a
+}
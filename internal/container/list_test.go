@@ -0,0 +1,32 @@
+package container
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestList(t *testing.T) {
+	list := NewList[string]()
+	if got := list.ToSlice(); len(got) != 0 {
+		t.Errorf("want empty, got %v", got)
+	}
+
+	list.Add("a")
+	list.Add("b")
+	other := NewList[string]()
+	other.Add("c")
+	list.Concat(other)
+	list.Concat(NewList[string]())
+
+	empty := NewList[string]()
+	empty.Concat(list)
+
+	for _, l := range []*List[string]{list, empty} {
+		if want := []string{"a", "b", "c"}; !reflect.DeepEqual(l.ToSlice(), want) {
+			t.Errorf("want: %v, got: %v", want, l.ToSlice())
+		}
+		if l.Len() != 3 {
+			t.Errorf("want 3 elements, got %d", l.Len())
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package container
+
+const (
+	pooledStackMinChunk = 16
+	pooledStackMaxChunk = 1024
+)
+
+// PooledStack is a Stack of linked nodes like LinkedStack, but it takes
+// nodes from chunked arrays and reuses popped nodes through a free list.
+// It allocates only when all the nodes are in use, for a chunk of nodes
+// which doubles up to 1024 nodes.
+type PooledStack[T any] struct {
+	last *pooledStackNode[T]
+	free *pooledStackNode[T] // the popped nodes linked by `parent`
+	// chunk is the rest of the nodes of the last chunk which have never
+	// been used.
+	chunk     []pooledStackNode[T]
+	chunkSize int
+	len       int
+}
+
+type pooledStackNode[T any] struct {
+	parent *pooledStackNode[T]
+	value  T
+}
+
+// NewPooledStack creates an empty PooledStack.
+func NewPooledStack[T any]() *PooledStack[T] {
+	return &PooledStack[T]{}
+}
+
+// Push adds the element.
+func (s *PooledStack[T]) Push(elem T) {
+	node := s.newNode()
+	node.parent = s.last
+	node.value = elem
+	s.last = node
+	s.len++
+}
+
+func (s *PooledStack[T]) newNode() *pooledStackNode[T] {
+	if s.free != nil {
+		node := s.free
+		s.free = node.parent
+		return node
+	}
+
+	if len(s.chunk) == 0 {
+		switch {
+		case s.chunkSize == 0:
+			s.chunkSize = pooledStackMinChunk
+		case s.chunkSize < pooledStackMaxChunk:
+			s.chunkSize *= 2
+		}
+		s.chunk = make([]pooledStackNode[T], s.chunkSize)
+	}
+	node := &s.chunk[0]
+	s.chunk = s.chunk[1:]
+	return node
+}
+
+// Pop removes the last element and returns it. The node goes to the free
+// list.
+func (s *PooledStack[T]) Pop() T {
+	node := s.last
+	value := node.value
+	s.last = node.parent
+
+	var zero T
+	node.value = zero // not to retain the references of the value
+	node.parent = s.free
+	s.free = node
+
+	s.len--
+	return value
+}
+
+// Peek returns the last element.
+func (s *PooledStack[T]) Peek() T {
+	return s.last.value
+}
+
+// Top returns the pointer to the last element.
+func (s *PooledStack[T]) Top() *T {
+	return &s.last.value
+}
+
+// Empty reports whether the stack has no elements.
+func (s *PooledStack[T]) Empty() bool {
+	return s.last == nil
+}
+
+// Len returns the number of the elements.
+func (s *PooledStack[T]) Len() int {
+	return s.len
+}
@@ -0,0 +1,40 @@
+package container
+
+import "testing"
+
+func TestPooledStackReusesNodes(t *testing.T) {
+	s := NewPooledStack[int]()
+	fill := func() {
+		for i := 0; i < 100; i++ {
+			s.Push(i)
+		}
+		for i := 99; i >= 0; i-- {
+			if got := s.Pop(); got != i {
+				t.Fatalf("want: %d, got: %d", i, got)
+			}
+		}
+	}
+
+	fill() // allocates the chunks
+	if allocs := testing.AllocsPerRun(10, fill); allocs != 0 {
+		t.Errorf("want no allocations for the popped nodes, got %v", allocs)
+	}
+}
+
+func TestPooledStackChunks(t *testing.T) {
+	s := NewPooledStack[int]()
+	for i := 0; i < 5000; i++ {
+		s.Push(i)
+	}
+	if s.chunkSize != pooledStackMaxChunk {
+		t.Errorf("want chunks of %d nodes, got %d", pooledStackMaxChunk, s.chunkSize)
+	}
+	for i := 4999; i >= 0; i-- {
+		if got := s.Pop(); got != i {
+			t.Fatalf("want: %d, got: %d", i, got)
+		}
+	}
+	if !s.Empty() || s.Len() != 0 {
+		t.Errorf("want empty, got %d elements", s.Len())
+	}
+}
@@ -0,0 +1,116 @@
+// Package container provides generic containers for the implementations of
+// enumeration, so that they can switch the backends of their stacks
+// independently of their algorithms.
+package container
+
+// Stack is a last-in-first-out collection.
+type Stack[T any] interface {
+	Push(elem T)
+	// Pop removes the last element and returns it. It panics if the stack is
+	// empty.
+	Pop() T
+	// Peek returns the last element. It panics if the stack is empty.
+	Peek() T
+	// Top returns the pointer to the last element, through which it can be
+	// updated in place. The pointer is valid until the next Push or Pop.
+	// It panics if the stack is empty.
+	Top() *T
+	Empty() bool
+	Len() int
+}
+
+// LinkedStack is a Stack of linked nodes, which allocates for each push.
+type LinkedStack[T any] struct {
+	last *linkedStackNode[T]
+	len  int
+}
+
+type linkedStackNode[T any] struct {
+	parent *linkedStackNode[T]
+	value  T
+}
+
+// NewLinkedStack creates an empty LinkedStack.
+func NewLinkedStack[T any]() *LinkedStack[T] {
+	return &LinkedStack[T]{}
+}
+
+// Push adds the element.
+func (s *LinkedStack[T]) Push(elem T) {
+	s.last = &linkedStackNode[T]{s.last, elem}
+	s.len++
+}
+
+// Pop removes the last element and returns it.
+func (s *LinkedStack[T]) Pop() T {
+	value := s.last.value
+	s.last = s.last.parent
+	s.len--
+	return value
+}
+
+// Peek returns the last element.
+func (s *LinkedStack[T]) Peek() T {
+	return s.last.value
+}
+
+// Top returns the pointer to the last element.
+func (s *LinkedStack[T]) Top() *T {
+	return &s.last.value
+}
+
+// Empty reports whether the stack has no elements.
+func (s *LinkedStack[T]) Empty() bool {
+	return s.last == nil
+}
+
+// Len returns the number of the elements.
+func (s *LinkedStack[T]) Len() int {
+	return s.len
+}
+
+// SliceStack is a Stack on a slice, which allocates only when the slice
+// grows.
+type SliceStack[T any] struct {
+	elems []T
+}
+
+// NewSliceStack creates an empty SliceStack.
+func NewSliceStack[T any]() *SliceStack[T] {
+	return &SliceStack[T]{}
+}
+
+// Push adds the element.
+func (s *SliceStack[T]) Push(elem T) {
+	s.elems = append(s.elems, elem)
+}
+
+// Pop removes the last element and returns it.
+func (s *SliceStack[T]) Pop() T {
+	last := len(s.elems) - 1
+	value := s.elems[last]
+	var zero T
+	s.elems[last] = zero // not to retain the references of the value
+	s.elems = s.elems[:last]
+	return value
+}
+
+// Peek returns the last element.
+func (s *SliceStack[T]) Peek() T {
+	return s.elems[len(s.elems)-1]
+}
+
+// Top returns the pointer to the last element.
+func (s *SliceStack[T]) Top() *T {
+	return &s.elems[len(s.elems)-1]
+}
+
+// Empty reports whether the stack has no elements.
+func (s *SliceStack[T]) Empty() bool {
+	return len(s.elems) == 0
+}
+
+// Len returns the number of the elements.
+func (s *SliceStack[T]) Len() int {
+	return len(s.elems)
+}
@@ -0,0 +1,59 @@
+package container
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStacks(t *testing.T) {
+	backends := map[string]func() Stack[int]{
+		"Linked": func() Stack[int] { return NewLinkedStack[int]() },
+		"Slice":  func() Stack[int] { return NewSliceStack[int]() },
+		"Pooled": func() Stack[int] { return NewPooledStack[int]() },
+	}
+
+	for name, newStack := range backends {
+		s := newStack()
+		if !s.Empty() || s.Len() != 0 {
+			t.Errorf("%s: want empty", name)
+		}
+
+		got := []int{}
+		for i := 0; i < 3; i++ {
+			s.Push(i)
+		}
+		*s.Top() += 10
+		if s.Peek() != 12 || s.Len() != 3 {
+			t.Errorf("%s: want the top 12 of 3 elements, got %d of %d", name, s.Peek(), s.Len())
+		}
+		got = append(got, s.Pop())
+		s.Push(3)
+		for !s.Empty() {
+			got = append(got, s.Pop())
+		}
+
+		if want := []int{12, 3, 1, 0}; !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: want: %v, got: %v", name, want, got)
+		}
+		if s.Len() != 0 {
+			t.Errorf("%s: want empty, got %d elements", name, s.Len())
+		}
+	}
+}
+
+func TestStackPanicsIfEmpty(t *testing.T) {
+	for name, s := range map[string]Stack[int]{
+		"Linked": NewLinkedStack[int](),
+		"Slice":  NewSliceStack[int](),
+		"Pooled": NewPooledStack[int](),
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: want a panic", name)
+				}
+			}()
+			s.Pop()
+		}()
+	}
+}